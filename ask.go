@@ -0,0 +1,73 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Ask creates a new thread with the given question, runs the assistant on it
+// and returns the assistant's plain-text reply.
+func (c *Client) Ask(ctx context.Context, assistantID, question string) (_ string, err error) {
+	ctx, op := c.startOperation(ctx, "Ask")
+	defer op.end(&err)
+
+	thread, err := c.CreateThread(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not create thread: %w", err)
+	}
+
+	return c.Continue(ctx, thread.ID, assistantID, question)
+}
+
+// Continue adds a user message to an existing thread, runs the assistant on it
+// and returns the assistant's plain-text reply.
+func (c *Client) Continue(ctx context.Context, threadID, assistantID, message string) (_ string, err error) {
+	ctx, op := c.startOperation(ctx, "Continue")
+	defer op.end(&err)
+
+	if _, err := c.AddMessage(ctx, CreateMessageInput{
+		ThreadID: threadID,
+		Message: ThreadMessage{
+			Role:    RoleUser,
+			Content: message,
+		},
+	}); err != nil {
+		return "", fmt.Errorf("could not add message: %w", err)
+	}
+
+	run, err := c.RunThread(ctx, threadID, assistantID)
+	if err != nil {
+		return "", fmt.Errorf("could not run thread: %w", err)
+	}
+
+	if err := c.WaitForRun(ctx, threadID, run.ID); err != nil {
+		return "", fmt.Errorf("could not wait for run: %w", err)
+	}
+
+	messages, err := c.GetMessages(ctx, threadID, ListParams{})
+	if err != nil {
+		return "", fmt.Errorf("could not get messages: %w", err)
+	}
+	return latestAssistantText(messages, run.ID)
+}
+
+// latestAssistantText returns the text of the most recent assistant message
+// produced by the given run. Messages are expected in the API's default
+// newest-first order.
+func latestAssistantText(messages *ThreadMessageList, runID string) (string, error) {
+	for _, msg := range messages.Data {
+		if msg.Role != RoleAssistant || msg.RunID != runID {
+			continue
+		}
+
+		var parts []string
+		for _, content := range msg.Content {
+			if content.Type == "text" {
+				parts = append(parts, content.Text.Value)
+			}
+		}
+		return strings.Join(parts, "\n"), nil
+	}
+	return "", fmt.Errorf("no assistant reply found for run '%s'", runID)
+}
@@ -0,0 +1,138 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Ask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		messages    []MessageContent
+		runStatus   string
+		want        string
+		expectError bool
+	}{
+		{
+			name: "returns assistant reply",
+			messages: []MessageContent{
+				{
+					Role:  RoleAssistant,
+					RunID: "run_123",
+					Content: []Content{
+						{Type: "text", Text: TextValue{Value: "Hi there!"}},
+					},
+				},
+				{
+					Role: RoleUser,
+					Content: []Content{
+						{Type: "text", Text: TextValue{Value: "Hello"}},
+					},
+				},
+			},
+			runStatus: RunStatusCompleted,
+			want:      "Hi there!",
+		},
+		{
+			name: "no assistant reply",
+			messages: []MessageContent{
+				{Role: RoleUser, Content: []Content{{Type: "text", Text: TextValue{Value: "Hello"}}}},
+			},
+			runStatus:   RunStatusCompleted,
+			expectError: true,
+		},
+		{
+			name:        "run fails",
+			runStatus:   RunStatusFailed,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /threads", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(Thread{ID: "thread_123"})
+			})
+			mux.HandleFunc("POST /threads/thread_123/messages", func(w http.ResponseWriter, r *http.Request) {
+				var message ThreadMessage
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+				require.Equal(t, ThreadMessage{Role: RoleUser, Content: "Hello"}, message)
+				json.NewEncoder(w).Encode(MessageContent{ID: "msg_1", Role: RoleUser})
+			})
+			mux.HandleFunc("POST /threads/thread_123/runs", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(Run{ID: "run_123", Status: RunStatusQueued})
+			})
+			mux.HandleFunc("GET /threads/thread_123/runs/run_123", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(Run{ID: "run_123", Status: tt.runStatus})
+			})
+			mux.HandleFunc("GET /threads/thread_123/messages", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(ThreadMessageList{Object: "list", Data: tt.messages})
+			})
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			got, err := client.Ask(context.Background(), "asst_123", "Hello")
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_Continue(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /threads/thread_123/messages", func(w http.ResponseWriter, r *http.Request) {
+		var message ThreadMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		require.Equal(t, ThreadMessage{Role: RoleUser, Content: "And tomorrow?"}, message)
+		json.NewEncoder(w).Encode(MessageContent{ID: "msg_2", Role: RoleUser})
+	})
+	mux.HandleFunc("POST /threads/thread_123/runs", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Run{ID: "run_456", Status: RunStatusQueued})
+	})
+	mux.HandleFunc("GET /threads/thread_123/runs/run_456", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Run{ID: "run_456", Status: RunStatusCompleted})
+	})
+	mux.HandleFunc("GET /threads/thread_123/messages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ThreadMessageList{
+			Object: "list",
+			Data: []MessageContent{
+				{Role: RoleAssistant, RunID: "run_456", Content: []Content{{Type: "text", Text: TextValue{Value: "Rainy."}}}},
+				{Role: RoleUser, Content: []Content{{Type: "text", Text: TextValue{Value: "And tomorrow?"}}}},
+				{Role: RoleAssistant, RunID: "run_123", Content: []Content{{Type: "text", Text: TextValue{Value: "Sunny."}}}},
+			},
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	got, err := client.Continue(context.Background(), "thread_123", "asst_123", "And tomorrow?")
+	require.NoError(t, err)
+	require.Equal(t, "Rainy.", got)
+}
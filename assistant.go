@@ -1,121 +1,184 @@
 package openai
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+)
 
-	"github.com/wiselead-ai/httpclient"
+// Range of sampling temperatures accepted by the API
+const (
+	minTemperature = 0.0
+	maxTemperature = 2.0
 )
 
-func (c *Client) CreateAssistant(ctx context.Context, in *CreateAssistantInput) (*Assistant, error) {
-	jsonData, err := json.Marshal(in)
-	if err != nil {
-		return nil, fmt.Errorf("could not marshal assistant config: %w", err)
+// Range of nucleus sampling probabilities accepted by the API
+const (
+	minTopP = 0.0
+	maxTopP = 1.0
+)
+
+// CreateAssistant creates an assistant, using DefaultAssistModel and
+// DefaultAssistTemp when no model or temperature is set.
+func (c *Client) CreateAssistant(ctx context.Context, in *CreateAssistantInput) (_ *Assistant, err error) {
+	ctx, op := c.startOperation(ctx, "CreateAssistant")
+	defer op.end(&err)
+
+	if in == nil {
+		return nil, fmt.Errorf("input cannot be nil")
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		c.baseURL+"/assistants",
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+	if err := validateTemperature(in.Temperature); err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	if err := validateTopP(in.TopP); err != nil {
+		return nil, err
+	}
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
-	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+	input := *in
+	if input.Model == "" {
+		input.Model = DefaultAssistModel
+	}
+	if input.Temperature == nil {
+		temp := DefaultAssistTemp
+		input.Temperature = &temp
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code '%d', response: '%s'", resp.StatusCode, string(b))
+	req, err := c.newRequest(ctx, http.MethodPost, "/assistants", input)
+	if err != nil {
+		return nil, err
 	}
 
 	var assistant Assistant
-	if err := json.NewDecoder(resp.Body).Decode(&assistant); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if err := c.sendRequest(req, &assistant); err != nil {
+		return nil, err
 	}
 	return &assistant, nil
 }
 
-func (c *Client) GetAssistant(ctx context.Context, assistantID string) (*Assistant, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		c.baseURL+"/assistants/"+assistantID,
-		nil,
-	)
+func (c *Client) GetAssistant(ctx context.Context, assistantID string) (_ *Assistant, err error) {
+	ctx, op := c.startOperation(ctx, "GetAssistant")
+	defer op.end(&err)
+
+	if c.assistants != nil {
+		if assistant, ok := c.assistants.get(assistantID); ok {
+			return assistant, nil
+		}
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/assistants/"+assistantID, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	var assistant Assistant
+	if err := c.sendRequest(req, &assistant); err != nil {
+		return nil, err
+	}
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
+	if c.assistants != nil {
+		c.assistants.set(&assistant)
+	}
+	return &assistant, nil
+}
+
+// GetAssistantVectorStores returns the IDs of the vector stores the assistant
+// searches with its file_search tool, if any.
+func (c *Client) GetAssistantVectorStores(ctx context.Context, assistantID string) (_ []string, err error) {
+	ctx, op := c.startOperation(ctx, "GetAssistantVectorStores")
+	defer op.end(&err)
+
+	assistant, err := c.GetAssistant(ctx, assistantID)
 	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code '%d', response: '%s'", resp.StatusCode, string(b))
+	if assistant.ToolResources == nil || assistant.ToolResources.FileSearch == nil {
+		return nil, nil
+	}
+	return assistant.ToolResources.FileSearch.VectorStoreIDs, nil
+}
+
+func (c *Client) ModifyAssistant(ctx context.Context, assistantID string, in *ModifyAssistantInput) (_ *Assistant, err error) {
+	ctx, op := c.startOperation(ctx, "ModifyAssistant")
+	defer op.end(&err)
+
+	if in == nil {
+		return nil, fmt.Errorf("input cannot be nil")
+	}
+
+	if err := validateTemperature(in.Temperature); err != nil {
+		return nil, err
+	}
+
+	if err := validateTopP(in.TopP); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/assistants/"+assistantID, in)
+	if err != nil {
+		return nil, err
 	}
 
 	var assistant Assistant
-	if err := json.NewDecoder(resp.Body).Decode(&assistant); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if err := c.sendRequest(req, &assistant); err != nil {
+		return nil, err
+	}
+
+	if c.assistants != nil {
+		c.assistants.set(&assistant)
 	}
 	return &assistant, nil
 }
 
-func (c *Client) ModifyAssistant(ctx context.Context, assistantID string, in *ModifyAssistantInput) (*Assistant, error) {
-	jsonData, err := json.Marshal(in)
+func (c *Client) ListAssistants(ctx context.Context, params ListParams) (_ *AssistantList, err error) {
+	ctx, op := c.startOperation(ctx, "ListAssistants")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/assistants"+params.query(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal assistant config: %w", err)
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		c.baseURL+"/assistants/"+assistantID, // Remove /v1 prefix
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+	var assistants AssistantList
+	if err := c.sendRequest(req, &assistants); err != nil {
+		return nil, err
 	}
+	return &assistants, nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+func validateTemperature(t *float64) error {
+	if t != nil && (*t < minTemperature || *t > maxTemperature) {
+		return fmt.Errorf("temperature %g is out of range, must be between %g and %g", *t, minTemperature, maxTemperature)
+	}
+	return nil
+}
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
-	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+func validateTopP(p *float64) error {
+	if p != nil && (*p < minTopP || *p > maxTopP) {
+		return fmt.Errorf("top_p %g is out of range, must be between %g and %g", *p, minTopP, maxTopP)
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code '%d', response: '%s'", resp.StatusCode, string(b))
+func (f ResponseFormat) MarshalJSON() ([]byte, error) {
+	if f.Type == ResponseFormatAuto {
+		return json.Marshal(ResponseFormatAuto)
 	}
 
-	var assistant Assistant
-	if err := json.NewDecoder(resp.Body).Decode(&assistant); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	type responseFormat ResponseFormat
+	return json.Marshal(responseFormat(f))
+}
+
+func (f *ResponseFormat) UnmarshalJSON(b []byte) error {
+	var format string
+	if err := json.Unmarshal(b, &format); err == nil {
+		*f = ResponseFormat{Type: format}
+		return nil
 	}
-	return &assistant, nil
+
+	type responseFormat ResponseFormat
+	return json.Unmarshal(b, (*responseFormat)(f))
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -44,6 +45,15 @@ func TestClient_CreateAssistant(t *testing.T) {
 			},
 			serverStatus: http.StatusOK,
 		},
+		{
+			name: "temperature out of range",
+			input: &CreateAssistantInput{
+				Model:       "gpt-4",
+				Name:        "Test Assistant",
+				Temperature: ptr(20.0),
+			},
+			expectedError: true,
+		},
 		{
 			name: "server error",
 			input: &CreateAssistantInput{
@@ -75,6 +85,7 @@ func TestClient_CreateAssistant(t *testing.T) {
 				httpClient: server.Client(),
 				baseURL:    server.URL,
 				apiKey:     "test-key",
+				retrySleep: withoutRetryDelay(nil, nil),
 			}
 
 			result, err := client.CreateAssistant(context.Background(), tt.input)
@@ -89,6 +100,34 @@ func TestClient_CreateAssistant(t *testing.T) {
 	}
 }
 
+func TestClient_CreateAssistant_Defaults(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input CreateAssistantInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		require.Equal(t, DefaultAssistModel, input.Model)
+		require.NotNil(t, input.Temperature)
+		require.Equal(t, DefaultAssistTemp, *input.Temperature)
+
+		json.NewEncoder(w).Encode(Assistant{ID: "asst_123", Model: input.Model})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		apiKey:     "test-key",
+	}
+
+	in := &CreateAssistantInput{Name: "Test Assistant"}
+	result, err := client.CreateAssistant(context.Background(), in)
+	require.NoError(t, err)
+	require.Equal(t, DefaultAssistModel, result.Model)
+	require.Empty(t, in.Model, "caller's input must not be modified")
+	require.Nil(t, in.Temperature, "caller's input must not be modified")
+}
+
 func TestClient_GetAssistant(t *testing.T) {
 	t.Parallel()
 
@@ -116,6 +155,32 @@ func TestClient_GetAssistant(t *testing.T) {
 			},
 			serverStatus: http.StatusOK,
 		},
+		{
+			name:        "sampling settings",
+			assistantID: "asst_456",
+			serverResponse: &Assistant{
+				ID:             "asst_456",
+				Object:         "assistant",
+				Model:          "gpt-4o",
+				Temperature:    ptr(0.7),
+				TopP:           ptr(0.9),
+				ResponseFormat: &ResponseFormat{Type: ResponseFormatAuto},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:        "tool resources",
+			assistantID: "asst_789",
+			serverResponse: &Assistant{
+				ID:     "asst_789",
+				Object: "assistant",
+				Tools:  []Tool{{Type: ToolTypeFileSearch}},
+				ToolResources: &ToolResources{
+					FileSearch: &FileSearch{VectorStoreIDs: []string{"vs_123"}},
+				},
+			},
+			serverStatus: http.StatusOK,
+		},
 		{
 			name:          "not found",
 			assistantID:   "asst_nonexistent",
@@ -157,6 +222,131 @@ func TestClient_GetAssistant(t *testing.T) {
 	}
 }
 
+func TestClient_GetAssistantVectorStores(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		serverResponse string
+		serverStatus   int
+		want           []string
+		expectError    bool
+	}{
+		{
+			name:           "file search stores",
+			serverResponse: `{"id":"asst_123","tool_resources":{"file_search":{"vector_store_ids":["vs_1","vs_2"]}}}`,
+			serverStatus:   http.StatusOK,
+			want:           []string{"vs_1", "vs_2"},
+		},
+		{
+			name:           "code interpreter only",
+			serverResponse: `{"id":"asst_123","tool_resources":{"code_interpreter":{"file_ids":["file-1"]}}}`,
+			serverStatus:   http.StatusOK,
+		},
+		{
+			name:           "no tool resources",
+			serverResponse: `{"id":"asst_123"}`,
+			serverStatus:   http.StatusOK,
+		},
+		{
+			name:         "not found",
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/assistants/asst_123", r.URL.Path)
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverResponse))
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient: server.Client(),
+				baseURL:    server.URL,
+				apiKey:     "test-key",
+			}
+
+			got, err := client.GetAssistantVectorStores(context.Background(), "asst_123")
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_GetAssistant_Cache(t *testing.T) {
+	t.Parallel()
+
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			if r.URL.Path == "/assistants/asst_missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(Assistant{ID: "asst_123", Name: "Original"})
+		case http.MethodPost:
+			json.NewEncoder(w).Encode(Assistant{ID: "asst_123", Name: "Modified"})
+		}
+	}))
+	defer server.Close()
+
+	now := time.Unix(1700000000, 0)
+	client := &Client{
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		apiKey:     "test-key",
+	}
+	WithAssistantCache(time.Minute)(client)
+	client.assistants.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for range 3 {
+		assistant, err := client.GetAssistant(ctx, "asst_123")
+		require.NoError(t, err)
+		require.Equal(t, "Original", assistant.Name)
+	}
+	require.Equal(t, 1, gets)
+
+	// A cached copy can be modified by the caller without affecting the cache.
+	assistant, err := client.GetAssistant(ctx, "asst_123")
+	require.NoError(t, err)
+	assistant.Name = "Changed locally"
+
+	_, err = client.ModifyAssistant(ctx, "asst_123", &ModifyAssistantInput{Description: "Modified"})
+	require.NoError(t, err)
+
+	assistant, err = client.GetAssistant(ctx, "asst_123")
+	require.NoError(t, err)
+	require.Equal(t, "Modified", assistant.Name)
+	require.Equal(t, 1, gets)
+
+	now = now.Add(time.Minute)
+	assistant, err = client.GetAssistant(ctx, "asst_123")
+	require.NoError(t, err)
+	require.Equal(t, "Original", assistant.Name)
+	require.Equal(t, 2, gets)
+
+	for range 2 {
+		_, err = client.GetAssistant(ctx, "asst_missing")
+		require.Error(t, err)
+	}
+	require.Equal(t, 4, gets)
+}
+
 func TestClient_ModifyAssistant(t *testing.T) {
 	t.Parallel()
 
@@ -191,6 +381,27 @@ func TestClient_ModifyAssistant(t *testing.T) {
 			},
 			serverStatus: http.StatusOK,
 		},
+		{
+			name:        "negative temperature",
+			assistantID: "asst_123",
+			input: &ModifyAssistantInput{
+				Temperature: ptr(-0.5),
+			},
+			expectedError: true,
+		},
+		{
+			name:        "top_p out of range",
+			assistantID: "asst_123",
+			input: &ModifyAssistantInput{
+				TopP: ptr(1.5),
+			},
+			expectedError: true,
+		},
+		{
+			name:          "nil input",
+			assistantID:   "asst_123",
+			expectedError: true,
+		},
 		{
 			name:        "invalid modification",
 			assistantID: "asst_123",
@@ -236,3 +447,129 @@ func TestClient_ModifyAssistant(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ListAssistants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		params         ListParams
+		wantQuery      string
+		serverResponse *AssistantList
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name:      "first page",
+			params:    ListParams{Limit: 2, Order: OrderDesc},
+			wantQuery: "limit=2&order=desc",
+			serverResponse: &AssistantList{
+				Object:  "list",
+				Data:    []Assistant{{ID: "asst_2"}, {ID: "asst_1"}},
+				FirstID: "asst_2",
+				LastID:  "asst_1",
+				HasMore: true,
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:      "next page",
+			params:    ListParams{After: "asst_1"},
+			wantQuery: "after=asst_1",
+			serverResponse: &AssistantList{
+				Object:  "list",
+				Data:    []Assistant{{ID: "asst_0"}},
+				FirstID: "asst_0",
+				LastID:  "asst_0",
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "unauthorized",
+			serverStatus:  http.StatusUnauthorized,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/assistants", r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, tt.wantQuery, r.URL.RawQuery)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient: server.Client(),
+				baseURL:    server.URL,
+				apiKey:     "test-key",
+			}
+
+			result, err := client.ListAssistants(context.Background(), tt.params)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestResponseFormat_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format ResponseFormat
+		json   string
+	}{
+		{
+			name:   "auto",
+			format: ResponseFormat{Type: ResponseFormatAuto},
+			json:   `"auto"`,
+		},
+		{
+			name:   "json object",
+			format: ResponseFormat{Type: ResponseFormatJSONObject},
+			json:   `{"type":"json_object"}`,
+		},
+		{
+			name: "json schema",
+			format: ResponseFormat{
+				Type: ResponseFormatJSONSchema,
+				JSONSchema: &JSONSchema{
+					Name:   "answer",
+					Schema: map[string]any{"type": "object"},
+					Strict: ptr(true),
+				},
+			},
+			json: `{"type":"json_schema","json_schema":{"name":"answer","schema":{"type":"object"},"strict":true}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(tt.format)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.json, string(b))
+
+			var format ResponseFormat
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &format))
+			require.Equal(t, tt.format, format)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package openai
+
+import (
+	"sync"
+	"time"
+)
+
+// assistantCache keeps the assistants fetched by GetAssistant for a fixed
+// time to live.
+type assistantCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]assistantCacheEntry
+}
+
+type assistantCacheEntry struct {
+	assistant Assistant
+	expiresAt time.Time
+}
+
+func newAssistantCache(ttl time.Duration) *assistantCache {
+	return &assistantCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]assistantCacheEntry),
+	}
+}
+
+// get returns a copy of the cached assistant, if it has not expired.
+func (c *assistantCache) get(assistantID string) (*Assistant, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[assistantID]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, assistantID)
+		return nil, false
+	}
+	assistant := entry.assistant
+	return &assistant, true
+}
+
+func (c *assistantCache) set(assistant *Assistant) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[assistant.ID] = assistantCacheEntry{
+		assistant: *assistant,
+		expiresAt: c.now().Add(c.ttl),
+	}
+}
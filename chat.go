@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (c *Client) CreateChatCompletion(ctx context.Context, in ChatCompletionRequest) (_ *ChatCompletionResponse, err error) {
+	ctx, op := c.startOperation(ctx, "CreateChatCompletion")
+	defer op.end(&err)
+
+	if in.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+
+	if len(in.Messages) == 0 {
+		return nil, fmt.Errorf("at least one message is required")
+	}
+
+	if err := validateTemperature(in.Temperature); err != nil {
+		return nil, err
+	}
+
+	if in.StreamOptions != nil {
+		return nil, fmt.Errorf("stream options are only supported by CreateChatCompletionStream")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/chat/completions", in)
+	if err != nil {
+		return nil, err
+	}
+
+	var completion ChatCompletionResponse
+	if err := c.sendRequest(req, &completion); err != nil {
+		return nil, err
+	}
+	return &completion, nil
+}
+
+// CreateChatCompletionStream creates a chat completion and streams its chunks
+// as events of type StreamEventChatCompletionChunk, whose data decodes into a
+// ChatCompletionChunk. When in.StreamOptions.IncludeUsage is set, the usage of
+// the completion follows in a final event of type StreamEventUsage, whose data
+// decodes into a Usage. If the stream breaks, a final event of type
+// StreamEventError is sent before the channel is closed.
+func (c *Client) CreateChatCompletionStream(ctx context.Context, in ChatCompletionRequest) (_ <-chan StreamEvent, err error) {
+	ctx, op := c.startOperation(ctx, "CreateChatCompletionStream")
+	defer op.end(&err)
+
+	if in.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+
+	if len(in.Messages) == 0 {
+		return nil, fmt.Errorf("at least one message is required")
+	}
+
+	if err := validateTemperature(in.Temperature); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/chat/completions", struct {
+		ChatCompletionRequest
+		Stream bool `json:"stream"`
+	}{
+		ChatCompletionRequest: in,
+		Stream:                true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	return c.streamWith(req, expandChatCompletionEvent)
+}
+
+// expandChatCompletionEvent names the unnamed chunks of a chat completion
+// stream, and follows the chunk carrying the usage with a usage event. That
+// chunk has no choices and is only relayed for completeness.
+func expandChatCompletionEvent(event StreamEvent) []StreamEvent {
+	if event.Event != "" {
+		return []StreamEvent{event}
+	}
+
+	event.Event = StreamEventChatCompletionChunk
+	var chunk struct {
+		Usage json.RawMessage `json:"usage"`
+	}
+	if err := json.Unmarshal(event.Data, &chunk); err != nil || len(chunk.Usage) == 0 || string(chunk.Usage) == "null" {
+		return []StreamEvent{event}
+	}
+	return []StreamEvent{event, {Event: StreamEventUsage, Data: chunk.Usage}}
+}
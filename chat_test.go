@@ -0,0 +1,242 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateChatCompletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          ChatCompletionRequest
+		serverResponse *ChatCompletionResponse
+		serverStatus   int
+		expectError    bool
+	}{
+		{
+			name: "successful completion",
+			input: ChatCompletionRequest{
+				Model: "gpt-4o-mini",
+				Messages: []ChatMessage{
+					{Role: RoleSystem, Content: "You are terse."},
+					{Role: RoleUser, Content: "Say hi"},
+				},
+				Temperature: ptr(0.2),
+				MaxTokens:   16,
+			},
+			serverResponse: &ChatCompletionResponse{
+				ID:      "chatcmpl-123",
+				Object:  "chat.completion",
+				Created: 1699009709,
+				Model:   "gpt-4o-mini",
+				Choices: []ChatCompletionChoice{
+					{
+						Index:        0,
+						Message:      ChatMessage{Role: RoleAssistant, Content: "Hi"},
+						FinishReason: "stop",
+					},
+				},
+				Usage: &Usage{PromptTokens: 12, CompletionTokens: 1, TotalTokens: 13},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name: "tool call",
+			input: ChatCompletionRequest{
+				Model:    "gpt-4o-mini",
+				Messages: []ChatMessage{{Role: RoleUser, Content: "Weather in Lisbon?"}},
+				Tools: []Tool{
+					{
+						Type: ToolTypeFunction,
+						Function: &FunctionDefinition{
+							Name:       "get_weather",
+							Parameters: map[string]any{"type": "object"},
+						},
+					},
+				},
+			},
+			serverResponse: &ChatCompletionResponse{
+				ID: "chatcmpl-456",
+				Choices: []ChatCompletionChoice{
+					{
+						Message: ChatMessage{
+							Role: RoleAssistant,
+							ToolCalls: []ToolCall{
+								{ID: "call_1", Type: ToolTypeFunction, Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Lisbon"}`}},
+							},
+						},
+						FinishReason: "tool_calls",
+					},
+				},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:        "missing model",
+			input:       ChatCompletionRequest{Messages: []ChatMessage{{Role: RoleUser, Content: "Hi"}}},
+			expectError: true,
+		},
+		{
+			name:        "missing messages",
+			input:       ChatCompletionRequest{Model: "gpt-4o-mini"},
+			expectError: true,
+		},
+		{
+			name: "bad request",
+			input: ChatCompletionRequest{
+				Model:    "unknown-model",
+				Messages: []ChatMessage{{Role: RoleUser, Content: "Hi"}},
+			},
+			serverStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/chat/completions", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				var input ChatCompletionRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+				require.Equal(t, tt.input, input)
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.CreateChatCompletion(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_CreateChatCompletionStream(t *testing.T) {
+	t.Parallel()
+
+	chunk := func(content string) string {
+		return `data: {"id":"chatcmpl-123","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"role":"assistant","content":"` + content + `"}}]}` + "\n\n"
+	}
+
+	tests := []struct {
+		name        string
+		in          ChatCompletionRequest
+		body        string
+		wantRequest string
+		wantEvents  []string
+		wantContent string
+		wantUsage   *Usage
+		expectError bool
+	}{
+		{
+			name: "streams chunks",
+			in: ChatCompletionRequest{
+				Model:    "gpt-4o",
+				Messages: []ChatMessage{{Role: "user", Content: "Hi"}},
+			},
+			body:        chunk("Hel") + chunk("lo") + "data: [DONE]\n\n",
+			wantRequest: `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}],"stream":true}`,
+			wantEvents:  []string{StreamEventChatCompletionChunk, StreamEventChatCompletionChunk},
+			wantContent: "Hello",
+		},
+		{
+			name: "includes usage",
+			in: ChatCompletionRequest{
+				Model:         "gpt-4o",
+				Messages:      []ChatMessage{{Role: "user", Content: "Hi"}},
+				StreamOptions: &StreamOptions{IncludeUsage: true},
+			},
+			body: chunk("Hello") +
+				`data: {"id":"chatcmpl-123","object":"chat.completion.chunk","choices":[],"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}` + "\n\n" +
+				"data: [DONE]\n\n",
+			wantRequest: `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}],"stream":true,"stream_options":{"include_usage":true}}`,
+			wantEvents:  []string{StreamEventChatCompletionChunk, StreamEventChatCompletionChunk, StreamEventUsage},
+			wantContent: "Hello",
+			wantUsage:   &Usage{PromptTokens: 5, CompletionTokens: 1, TotalTokens: 6},
+		},
+		{
+			name:        "missing messages",
+			in:          ChatCompletionRequest{Model: "gpt-4o"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var invoked bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				invoked = true
+				require.Equal(t, "/chat/completions", r.URL.Path)
+				require.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+				var body json.RawMessage
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				require.JSONEq(t, tt.wantRequest, string(body))
+
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			events, err := client.CreateChatCompletionStream(context.Background(), tt.in)
+			if tt.expectError {
+				require.Error(t, err)
+				require.False(t, invoked)
+				return
+			}
+			require.NoError(t, err)
+
+			var (
+				gotEvents  []string
+				gotContent string
+				gotUsage   *Usage
+			)
+			for event := range events {
+				gotEvents = append(gotEvents, event.Event)
+				switch event.Event {
+				case StreamEventChatCompletionChunk:
+					var chunk ChatCompletionChunk
+					require.NoError(t, json.Unmarshal(event.Data, &chunk))
+					for _, choice := range chunk.Choices {
+						gotContent += choice.Delta.Content
+					}
+				case StreamEventUsage:
+					require.NoError(t, json.Unmarshal(event.Data, &gotUsage))
+				}
+			}
+			require.Equal(t, tt.wantEvents, gotEvents)
+			require.Equal(t, tt.wantContent, gotContent)
+			require.Equal(t, tt.wantUsage, gotUsage)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolveCitations returns the file citations of the message with the text
+// they quote. The quote is taken from the file_search results of the run
+// steps, as returned by GetRunStepsWithContent: a citation such as
+// 【4:1†source】 quotes the result at index 1 of a file_search call, provided
+// that result is of the cited file. Citations matching no result keep the
+// quote of the annotation itself.
+func ResolveCitations(msg MessageContent, steps *RunSteps) []ResolvedCitation {
+	var searches [][]FileSearchResult
+	fileNames := make(map[string]string)
+	if steps != nil {
+		for _, step := range steps.Data {
+			if step.StepDetails == nil {
+				continue
+			}
+			for _, call := range step.StepDetails.ToolCalls {
+				if call.FileSearch == nil {
+					continue
+				}
+				searches = append(searches, call.FileSearch.Results)
+				for _, result := range call.FileSearch.Results {
+					if _, ok := fileNames[result.FileID]; !ok && result.FileName != "" {
+						fileNames[result.FileID] = result.FileName
+					}
+				}
+			}
+		}
+	}
+
+	var citations []ResolvedCitation
+	for _, content := range msg.Content {
+		for _, ann := range content.Text.Annotations {
+			if ann.FileCitation == nil {
+				continue
+			}
+
+			citation := ResolvedCitation{
+				Text:       ann.Text,
+				StartIndex: ann.StartIndex,
+				EndIndex:   ann.EndIndex,
+				FileID:     ann.FileCitation.FileID,
+				FileName:   fileNames[ann.FileCitation.FileID],
+				Quote:      ann.FileCitation.Quote,
+			}
+			if result, ok := citedResult(searches, citation); ok {
+				citation.Quote = resultText(result)
+			}
+			citations = append(citations, citation)
+		}
+	}
+	return citations
+}
+
+// citedResult returns the file_search result the citation points to by the
+// result index of its marker, looking through the searches in order for one
+// whose result at that index is of the cited file and has content.
+func citedResult(searches [][]FileSearchResult, citation ResolvedCitation) (FileSearchResult, bool) {
+	index, ok := citationResultIndex(citation.Text)
+	if !ok {
+		return FileSearchResult{}, false
+	}
+	for _, results := range searches {
+		if index >= len(results) {
+			continue
+		}
+		if result := results[index]; result.FileID == citation.FileID && len(result.Content) > 0 {
+			return result, true
+		}
+	}
+	return FileSearchResult{}, false
+}
+
+// citationResultIndex parses the result index out of a citation marker such
+// as 【4:1†source】.
+func citationResultIndex(marker string) (int, bool) {
+	marker, ok := strings.CutPrefix(marker, "【")
+	if !ok {
+		return 0, false
+	}
+	position, _, ok := strings.Cut(marker, "†")
+	if !ok {
+		return 0, false
+	}
+	_, index, ok := strings.Cut(position, ":")
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 {
+		return 0, false
+	}
+	return i, true
+}
+
+func resultText(result FileSearchResult) string {
+	var parts []string
+	for _, c := range result.Content {
+		if c.Type == ContentTypeText && c.Text != "" {
+			parts = append(parts, c.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// ResolveCitationFileNames fills in the FileName of the citations that have
+// none, such as those resolved without run step content, with the name the
+// cited file was uploaded under. Upload files with UploadFileNamed so that
+// these names are the documents' own rather than generated ones.
+func (c *Client) ResolveCitationFileNames(ctx context.Context, citations []ResolvedCitation) (err error) {
+	ctx, op := c.startOperation(ctx, "ResolveCitationFileNames")
+	defer op.end(&err)
+
+	names := make(map[string]string)
+	for i := range citations {
+		citation := &citations[i]
+		if citation.FileName != "" || citation.FileID == "" {
+			continue
+		}
+
+		name, ok := names[citation.FileID]
+		if !ok {
+			file, err := c.GetFileMetadata(ctx, citation.FileID)
+			if err != nil {
+				return fmt.Errorf("could not get metadata of file '%s': %w", citation.FileID, err)
+			}
+			name = file.Filename
+			names[citation.FileID] = name
+		}
+		citation.FileName = name
+	}
+	return nil
+}
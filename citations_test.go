@@ -0,0 +1,182 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCitations(t *testing.T) {
+	t.Parallel()
+
+	msg := MessageContent{
+		ID:   "msg_123",
+		Role: RoleAssistant,
+		Content: []Content{{
+			Type: ContentTypeText,
+			Text: TextValue{
+				Value: "Refunds take 5 days【4:1†policy.pdf】, paid weekly【4:0†policy.pdf】, and need a receipt【4:1†faq.md】.",
+				Annotations: []Annotation{
+					{
+						Type:         "file_citation",
+						Text:         "【4:1†policy.pdf】",
+						StartIndex:   19,
+						EndIndex:     35,
+						FileCitation: &FileCitation{FileID: "file-policy"},
+					},
+					{
+						Type:         "file_citation",
+						Text:         "【4:0†policy.pdf】",
+						StartIndex:   48,
+						EndIndex:     64,
+						FileCitation: &FileCitation{FileID: "file-policy"},
+					},
+					{
+						Type:         "file_citation",
+						Text:         "【4:1†faq.md】",
+						StartIndex:   84,
+						EndIndex:     96,
+						FileCitation: &FileCitation{FileID: "file-faq", Quote: "Keep your receipt."},
+					},
+					{
+						Type: "file_path",
+						Text: "sandbox:/mnt/data/out.csv",
+					},
+				},
+			},
+		}},
+	}
+
+	steps := &RunSteps{
+		Data: []RunStep{
+			{
+				StepDetails: &StepDetail{
+					Type: "tool_calls",
+					ToolCalls: []ToolCall{{
+						ID:   "call_1",
+						Type: ToolTypeFileSearch,
+						FileSearch: &FileSearchCall{
+							Results: []FileSearchResult{
+								{
+									FileID:   "file-policy",
+									FileName: "policy.pdf",
+									Score:    0.4,
+									Content:  []FileSearchResultContent{{Type: ContentTypeText, Text: "Refunds are processed weekly."}},
+								},
+								{
+									FileID:   "file-policy",
+									FileName: "policy.pdf",
+									Score:    0.9,
+									Content: []FileSearchResultContent{
+										{Type: ContentTypeText, Text: "Refunds are processed"},
+										{Type: ContentTypeText, Text: "within 5 business days."},
+									},
+								},
+							},
+						},
+					}},
+				},
+			},
+			{StepDetails: &StepDetail{Type: "message_creation"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		steps *RunSteps
+		want  []ResolvedCitation
+	}{
+		{
+			name:  "quotes from run steps",
+			steps: steps,
+			want: []ResolvedCitation{
+				{
+					Text:       "【4:1†policy.pdf】",
+					StartIndex: 19,
+					EndIndex:   35,
+					FileID:     "file-policy",
+					FileName:   "policy.pdf",
+					Quote:      "Refunds are processed\nwithin 5 business days.",
+				},
+				{
+					Text:       "【4:0†policy.pdf】",
+					StartIndex: 48,
+					EndIndex:   64,
+					FileID:     "file-policy",
+					FileName:   "policy.pdf",
+					Quote:      "Refunds are processed weekly.",
+				},
+				{
+					Text:       "【4:1†faq.md】",
+					StartIndex: 84,
+					EndIndex:   96,
+					FileID:     "file-faq",
+					Quote:      "Keep your receipt.",
+				},
+			},
+		},
+		{
+			name: "without run steps",
+			want: []ResolvedCitation{
+				{Text: "【4:1†policy.pdf】", StartIndex: 19, EndIndex: 35, FileID: "file-policy"},
+				{Text: "【4:0†policy.pdf】", StartIndex: 48, EndIndex: 64, FileID: "file-policy"},
+				{Text: "【4:1†faq.md】", StartIndex: 84, EndIndex: 96, FileID: "file-faq", Quote: "Keep your receipt."},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, ResolveCitations(msg, tt.steps))
+		})
+	}
+}
+
+func TestClient_ResolveCitationFileNames(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		lookups []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/files/")
+		mu.Lock()
+		lookups = append(lookups, id)
+		mu.Unlock()
+
+		if id == "file-missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(FileDetails{ID: id, Filename: id + ".pdf"})
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	citations := []ResolvedCitation{
+		{FileID: "file-policy"},
+		{FileID: "file-faq", FileName: "faq.md"},
+		{FileID: "file-policy"},
+	}
+	require.NoError(t, client.ResolveCitationFileNames(context.Background(), citations))
+	require.Equal(t, "file-policy.pdf", citations[0].FileName)
+	require.Equal(t, "faq.md", citations[1].FileName)
+	require.Equal(t, "file-policy.pdf", citations[2].FileName)
+	require.Equal(t, []string{"file-policy"}, lookups)
+
+	err := client.ResolveCitationFileNames(context.Background(), []ResolvedCitation{{FileID: "file-missing"}})
+	require.ErrorContains(t, err, "file-missing")
+}
@@ -0,0 +1,47 @@
+package openai
+
+import "sort"
+
+// ExtractCodeOutputs returns the code run by the code_interpreter tool calls
+// of the run steps, as returned by GetRunSteps, with its logs and generated
+// images. They are returned in the order the steps were created in, whatever
+// the order of the list.
+func ExtractCodeOutputs(steps *RunSteps) []CodeOutput {
+	if steps == nil {
+		return nil
+	}
+
+	ordered := make([]RunStep, len(steps.Data))
+	copy(ordered, steps.Data)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].CreatedAt < ordered[j].CreatedAt
+	})
+
+	var outputs []CodeOutput
+	for _, step := range ordered {
+		if step.StepDetails == nil {
+			continue
+		}
+		for _, call := range step.StepDetails.ToolCalls {
+			if call.CodeInterpreter == nil {
+				continue
+			}
+
+			output := CodeOutput{
+				StepID:     step.ID,
+				ToolCallID: call.ID,
+				Input:      call.CodeInterpreter.Input,
+			}
+			for _, o := range call.CodeInterpreter.Outputs {
+				switch {
+				case o.Type == "logs":
+					output.Logs = append(output.Logs, o.Logs)
+				case o.Type == "image" && o.Image != nil:
+					output.ImageFileIDs = append(output.ImageFileIDs, o.Image.FileID)
+				}
+			}
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs
+}
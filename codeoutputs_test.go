@@ -0,0 +1,81 @@
+package openai
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractCodeOutputs(t *testing.T) {
+	t.Parallel()
+
+	// Run steps are listed newest first by default.
+	body := `{
+		"object": "list",
+		"data": [
+			{
+				"id": "step_2",
+				"created_at": 1700000002,
+				"step_details": {
+					"type": "tool_calls",
+					"tool_calls": [{
+						"id": "call_2",
+						"type": "code_interpreter",
+						"code_interpreter": {
+							"input": "plt.plot(x, y)\nplt.savefig('out.png')",
+							"outputs": [
+								{"type": "image", "image": {"file_id": "file-plot"}},
+								{"type": "logs", "logs": "saved"}
+							]
+						}
+					}]
+				}
+			},
+			{
+				"id": "step_msg",
+				"created_at": 1700000001,
+				"step_details": {"type": "message_creation"}
+			},
+			{
+				"id": "step_1",
+				"created_at": 1700000000,
+				"step_details": {
+					"type": "tool_calls",
+					"tool_calls": [
+						{"id": "call_fs", "type": "file_search", "file_search": {}},
+						{
+							"id": "call_1",
+							"type": "code_interpreter",
+							"code_interpreter": {
+								"input": "print(2 + 2)",
+								"outputs": [{"type": "logs", "logs": "4\n"}]
+							}
+						}
+					]
+				}
+			}
+		]
+	}`
+
+	var steps RunSteps
+	require.NoError(t, json.Unmarshal([]byte(body), &steps))
+
+	require.Equal(t, []CodeOutput{
+		{
+			StepID:     "step_1",
+			ToolCallID: "call_1",
+			Input:      "print(2 + 2)",
+			Logs:       []string{"4\n"},
+		},
+		{
+			StepID:       "step_2",
+			ToolCallID:   "call_2",
+			Input:        "plt.plot(x, y)\nplt.savefig('out.png')",
+			Logs:         []string{"saved"},
+			ImageFileIDs: []string{"file-plot"},
+		},
+	}, ExtractCodeOutputs(&steps))
+
+	require.Nil(t, ExtractCodeOutputs(nil))
+}
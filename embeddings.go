@@ -0,0 +1,83 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+func (c *Client) CreateEmbeddings(ctx context.Context, in EmbeddingRequest) (_ *EmbeddingResponse, err error) {
+	ctx, op := c.startOperation(ctx, "CreateEmbeddings")
+	defer op.end(&err)
+
+	if in.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+
+	if len(in.Input) == 0 {
+		return nil, fmt.Errorf("at least one input is required")
+	}
+
+	switch in.EncodingFormat {
+	case "", EncodingFormatFloat, EncodingFormatBase64:
+	default:
+		return nil, fmt.Errorf("unsupported encoding format '%s'", in.EncodingFormat)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/embeddings", in)
+	if err != nil {
+		return nil, err
+	}
+
+	var embeddings EmbeddingResponse
+	if err := c.sendRequest(req, &embeddings); err != nil {
+		return nil, err
+	}
+	return &embeddings, nil
+}
+
+// UnmarshalJSON decodes an embedding whose vector is either an array of floats
+// or, when requested with EncodingFormatBase64, a base64 string of
+// little-endian float32 values.
+func (e *Embedding) UnmarshalJSON(b []byte) error {
+	type embedding Embedding
+	var in struct {
+		embedding
+		Vector json.RawMessage `json:"embedding"`
+	}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	*e = Embedding(in.embedding)
+	if len(in.Vector) == 0 {
+		return nil
+	}
+	if in.Vector[0] != '"' {
+		return json.Unmarshal(in.Vector, &e.Vector)
+	}
+
+	var encoded string
+	if err := json.Unmarshal(in.Vector, &encoded); err != nil {
+		return err
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("could not decode base64 embedding: %w", err)
+	}
+
+	if len(raw)%4 != 0 {
+		return fmt.Errorf("base64 embedding has %d bytes, not a multiple of 4", len(raw))
+	}
+
+	e.Vector = make([]float32, len(raw)/4)
+	for i := range e.Vector {
+		e.Vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(raw[i*4:]))
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateEmbeddings(t *testing.T) {
+	t.Parallel()
+
+	vector := []float32{0.5, -1.25, 3}
+	raw := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(raw[i*4:], math.Float32bits(v))
+	}
+
+	tests := []struct {
+		name         string
+		input        EmbeddingRequest
+		serverBody   string
+		serverStatus int
+		expected     *EmbeddingResponse
+		expectError  bool
+	}{
+		{
+			name: "float encoding",
+			input: EmbeddingRequest{
+				Model: "text-embedding-3-small",
+				Input: []string{"hello"},
+			},
+			serverBody: `{"object":"list","model":"text-embedding-3-small",` +
+				`"data":[{"object":"embedding","index":0,"embedding":[0.5,-1.25,3]}],` +
+				`"usage":{"prompt_tokens":1,"total_tokens":1}}`,
+			serverStatus: http.StatusOK,
+			expected: &EmbeddingResponse{
+				Object: "list",
+				Model:  "text-embedding-3-small",
+				Data:   []Embedding{{Object: "embedding", Index: 0, Vector: vector}},
+				Usage:  &Usage{PromptTokens: 1, TotalTokens: 1},
+			},
+		},
+		{
+			name: "base64 encoding",
+			input: EmbeddingRequest{
+				Model:          "text-embedding-3-small",
+				Input:          []string{"hello", "world"},
+				Dimensions:     3,
+				EncodingFormat: EncodingFormatBase64,
+			},
+			serverBody: `{"object":"list","data":[` +
+				`{"object":"embedding","index":0,"embedding":"` + base64.StdEncoding.EncodeToString(raw) + `"},` +
+				`{"object":"embedding","index":1,"embedding":"` + base64.StdEncoding.EncodeToString(raw) + `"}]}`,
+			serverStatus: http.StatusOK,
+			expected: &EmbeddingResponse{
+				Object: "list",
+				Data: []Embedding{
+					{Object: "embedding", Index: 0, Vector: vector},
+					{Object: "embedding", Index: 1, Vector: vector},
+				},
+			},
+		},
+		{
+			name: "truncated base64 vector",
+			input: EmbeddingRequest{
+				Model:          "text-embedding-3-small",
+				Input:          []string{"hello"},
+				EncodingFormat: EncodingFormatBase64,
+			},
+			serverBody:   `{"data":[{"index":0,"embedding":"` + base64.StdEncoding.EncodeToString(raw[:5]) + `"}]}`,
+			serverStatus: http.StatusOK,
+			expectError:  true,
+		},
+		{
+			name:        "missing model",
+			input:       EmbeddingRequest{Input: []string{"hello"}},
+			expectError: true,
+		},
+		{
+			name:        "missing input",
+			input:       EmbeddingRequest{Model: "text-embedding-3-small"},
+			expectError: true,
+		},
+		{
+			name: "unsupported encoding format",
+			input: EmbeddingRequest{
+				Model:          "text-embedding-3-small",
+				Input:          []string{"hello"},
+				EncodingFormat: "int8",
+			},
+			expectError: true,
+		},
+		{
+			name: "bad request",
+			input: EmbeddingRequest{
+				Model: "unknown-model",
+				Input: []string{"hello"},
+			},
+			serverBody:   `{"error":{"message":"model not found"}}`,
+			serverStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/embeddings", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+				var input EmbeddingRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+				require.Equal(t, tt.input, input)
+
+				w.WriteHeader(tt.serverStatus)
+				io.WriteString(w, tt.serverBody)
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.CreateEmbeddings(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEmbedding_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+		want Embedding
+	}{
+		{
+			name: "float array",
+			data: `{"object":"embedding","index":1,"embedding":[0.5,-1]}`,
+			want: Embedding{Object: "embedding", Index: 1, Vector: []float32{0.5, -1}},
+		},
+		{
+			name: "base64",
+			data: `{"object":"embedding","embedding":"AAAAPwAAgL8="}`,
+			want: Embedding{Object: "embedding", Vector: []float32{0.5, -1}},
+		},
+		{
+			name: "missing embedding",
+			data: `{"object":"embedding","index":2}`,
+			want: Embedding{Object: "embedding", Index: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got Embedding
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &got))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package openai
+
+import "context"
+
+// ErrorHandler is called with the name of a client method, such as "GetRun",
+// and the error it is about to return.
+type ErrorHandler func(op string, err error)
+
+// WithErrorHandler sets a handler called whenever a method of the client
+// returns an error, to count and alert on failures in one place rather than at
+// every call site. A method failing because another one it calls did, like
+// RunThread when polling the run fails, is reported once, under the name of
+// the method called. Errors delivered on the channels of streams are not
+// reported. The handler may be called concurrently and must not block.
+func WithErrorHandler(handler ErrorHandler) ClientOption {
+	return func(c *Client) {
+		c.errorHandler = handler
+	}
+}
+
+type operationKey struct{}
+
+// operation reports the error of a method to the error handler.
+type operation struct {
+	name    string
+	handler ErrorHandler
+}
+
+// startOperation starts the operation of the named method, returning nil when
+// there is no error handler or when the method is called from within another
+// operation, which reports the error instead. The returned context marks the
+// calls made with it as nested.
+func (c *Client) startOperation(ctx context.Context, name string) (context.Context, *operation) {
+	if c.errorHandler == nil || ctx.Value(operationKey{}) != nil {
+		return ctx, nil
+	}
+	op := &operation{name: name, handler: c.errorHandler}
+	return context.WithValue(ctx, operationKey{}, op), op
+}
+
+// end reports the error the method returns, if any, meant to be deferred with
+// a pointer to its named error result.
+func (op *operation) end(err *error) {
+	if op != nil && *err != nil {
+		op.handler(op.name, *err)
+	}
+}
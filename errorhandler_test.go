@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	type report struct {
+		op  string
+		err error
+	}
+
+	tests := []struct {
+		name    string
+		call    func(c *Client) error
+		wantOps []string
+	}{
+		{
+			name: "failed request",
+			call: func(c *Client) error {
+				_, err := c.GetThread(context.Background(), "thread_missing")
+				return err
+			},
+			wantOps: []string{"GetThread"},
+		},
+		{
+			name: "nested call is reported once",
+			call: func(c *Client) error {
+				_, err := c.GetFileContent(context.Background(), "file_missing")
+				return err
+			},
+			wantOps: []string{"GetFileContent"},
+		},
+		{
+			name: "invalid input",
+			call: func(c *Client) error {
+				return c.DeleteFile(context.Background(), "")
+			},
+			wantOps: []string{"DeleteFile"},
+		},
+		{
+			name: "success",
+			call: func(c *Client) error {
+				_, err := c.GetThread(context.Background(), "thread_123")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/threads/thread_123" {
+					json.NewEncoder(w).Encode(Thread{ID: "thread_123"})
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			var (
+				mu      sync.Mutex
+				reports []report
+			)
+			client := New(slog.Default(), "test-key", server.Client(),
+				WithBaseURL(server.URL),
+				WithErrorHandler(func(op string, err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					reports = append(reports, report{op: op, err: err})
+				}),
+			)
+
+			err := tt.call(client)
+
+			var ops []string
+			for _, r := range reports {
+				ops = append(ops, r.op)
+				require.Equal(t, err, r.err)
+			}
+			require.Equal(t, tt.wantOps, ops)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrContentFiltered is returned when a run was blocked by OpenAI's content
+// filters, as opposed to failing for a transient or server-side reason.
+var ErrContentFiltered = errors.New("run blocked by content filter")
+
+// ErrRunRequiresAction is returned by WaitForRun when the context is done
+// while the run waits for tool outputs that nobody submitted. Pass a handler
+// with WithToolCallHandler to have WaitForRun submit them.
+var ErrRunRequiresAction = errors.New("run requires action")
+
+// APIError is returned by the client methods when the API responds with a
+// non-success status code. The fields other than StatusCode and Body are taken
+// from OpenAI's error envelope and are empty when the response doesn't use it.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Type       string
+	Param      string
+	Code       string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code '%d', response: '%s'", e.StatusCode, e.Body)
+}
+
+// newAPIError reads the body of a non-success response and parses it as
+// OpenAI's error envelope.
+func newAPIError(resp *http.Response) *APIError {
+	b, _ := io.ReadAll(resp.Body)
+	apiErr := APIError{
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(b)),
+	}
+
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(b, &envelope); err != nil || len(envelope.Error) == 0 {
+		return &apiErr
+	}
+
+	var details struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Param   string `json:"param"`
+		Code    any    `json:"code"`
+	}
+	if err := json.Unmarshal(envelope.Error, &details); err != nil {
+		// Some endpoints answer with a bare string instead of an object.
+		_ = json.Unmarshal(envelope.Error, &apiErr.Message)
+		return &apiErr
+	}
+
+	apiErr.Message = details.Message
+	apiErr.Type = details.Type
+	apiErr.Param = details.Param
+	if details.Code != nil {
+		apiErr.Code = fmt.Sprint(details.Code)
+	}
+	return &apiErr
+}
+
+func isContentFiltered(run *Run) bool {
+	if run.LastError != nil && run.LastError.Code == ContentFilterReason {
+		return true
+	}
+	return run.IncompleteDetails != nil && run.IncompleteDetails.Reason == ContentFilterReason
+}
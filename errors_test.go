@@ -0,0 +1,98 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       *APIError
+	}{
+		{
+			name:       "openai error envelope",
+			statusCode: http.StatusTooManyRequests,
+			body:       `{"error":{"message":"Rate limit reached","type":"requests","param":null,"code":"rate_limit_exceeded"}}`,
+			want: &APIError{
+				StatusCode: http.StatusTooManyRequests,
+				Message:    "Rate limit reached",
+				Type:       "requests",
+				Code:       "rate_limit_exceeded",
+				Body:       `{"error":{"message":"Rate limit reached","type":"requests","param":null,"code":"rate_limit_exceeded"}}`,
+			},
+		},
+		{
+			name:       "string error",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":"Can't add messages to thread"}`,
+			want: &APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "Can't add messages to thread",
+				Body:       `{"error":"Can't add messages to thread"}`,
+			},
+		},
+		{
+			name:       "plain text body",
+			statusCode: http.StatusBadGateway,
+			body:       "bad gateway\n",
+			want: &APIError{
+				StatusCode: http.StatusBadGateway,
+				Body:       "bad gateway",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			got := newAPIError(resp)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, fmt.Sprintf("unexpected status code '%d', response: '%s'", tt.statusCode, tt.want.Body), got.Error())
+		})
+	}
+}
+
+func TestAPIError_As(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"message":"No assistant found","type":"invalid_request_error","param":null,"code":null}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		apiKey:     "test-key",
+	}
+
+	_, err := client.GetAssistant(context.Background(), "asst_nonexistent")
+	require.Error(t, err)
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	require.Equal(t, "invalid_request_error", apiErr.Type)
+	require.Equal(t, "No assistant found", apiErr.Message)
+	require.Empty(t, apiErr.Code)
+}
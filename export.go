@@ -0,0 +1,95 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// runPageSize is the number of runs ExportThread requests per page, the
+// maximum the API allows.
+const runPageSize = 100
+
+// ExportThread assembles the whole conversation of the thread: its metadata,
+// every message in chronological order with its file citations resolved, and
+// every run with the total usage, ready to be serialized for compliance or
+// support. It costs a request per page of messages and runs, plus one for the
+// run steps of each run whose messages cite files.
+func (c *Client) ExportThread(ctx context.Context, threadID string) (_ *ThreadExport, err error) {
+	ctx, op := c.startOperation(ctx, "ExportThread")
+	defer op.end(&err)
+
+	if threadID == "" {
+		return nil, fmt.Errorf("thread ID is required")
+	}
+
+	thread, err := c.GetThread(ctx, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get thread: %w", err)
+	}
+
+	messages, err := c.GetAllMessages(ctx, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get messages: %w", err)
+	}
+	slices.Reverse(messages)
+
+	export := ThreadExport{
+		Thread:   *thread,
+		Messages: make([]ExportedMessage, 0, len(messages)),
+		Runs:     []Run{},
+	}
+
+	steps := make(map[string]*RunSteps)
+	for _, msg := range messages {
+		var runSteps *RunSteps
+		if msg.RunID != "" && hasFileCitations(msg) {
+			var ok bool
+			if runSteps, ok = steps[msg.RunID]; !ok {
+				runSteps, err = c.GetRunStepsWithContent(ctx, threadID, msg.RunID)
+				if err != nil {
+					return nil, fmt.Errorf("could not get steps of run '%s': %w", msg.RunID, err)
+				}
+				steps[msg.RunID] = runSteps
+			}
+		}
+
+		export.Messages = append(export.Messages, ExportedMessage{
+			MessageContent: msg,
+			Citations:      ResolveCitations(msg, runSteps),
+		})
+	}
+
+	params := ListParams{Limit: runPageSize, Order: OrderAsc}
+	for {
+		runs, err := c.ListRuns(ctx, threadID, params)
+		if err != nil {
+			return nil, fmt.Errorf("could not list runs: %w", err)
+		}
+
+		for _, run := range runs.Data {
+			if run.Usage != nil {
+				export.Usage.PromptTokens += run.Usage.PromptTokens
+				export.Usage.CompletionTokens += run.Usage.CompletionTokens
+				export.Usage.TotalTokens += run.Usage.TotalTokens
+			}
+		}
+		export.Runs = append(export.Runs, runs.Data...)
+
+		if !runs.HasMore || runs.LastID == "" {
+			return &export, nil
+		}
+		params.After = runs.LastID
+	}
+}
+
+func hasFileCitations(msg MessageContent) bool {
+	for _, content := range msg.Content {
+		for _, ann := range content.Text.Annotations {
+			if ann.FileCitation != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
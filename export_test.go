@@ -0,0 +1,138 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ExportThread(t *testing.T) {
+	t.Parallel()
+
+	question := MessageContent{
+		ID:       "msg_1",
+		ThreadID: "thread_123",
+		Role:     RoleUser,
+		Content:  []Content{{Type: ContentTypeText, Text: TextValue{Value: "How long do refunds take?"}}},
+	}
+	answer := MessageContent{
+		ID:       "msg_2",
+		ThreadID: "thread_123",
+		RunID:    "run_1",
+		Role:     RoleAssistant,
+		Content: []Content{{
+			Type: ContentTypeText,
+			Text: TextValue{
+				Value: "5 days【4:0†policy.pdf】",
+				Annotations: []Annotation{{
+					Type:         "file_citation",
+					Text:         "【4:0†policy.pdf】",
+					StartIndex:   6,
+					EndIndex:     22,
+					FileCitation: &FileCitation{FileID: "file-policy"},
+				}},
+			},
+		}},
+	}
+	runs := []Run{
+		{ID: "run_1", Status: RunStatusCompleted, Usage: &Usage{PromptTokens: 100, CompletionTokens: 20, TotalTokens: 120}},
+		{ID: "run_2", Status: RunStatusFailed},
+		{ID: "run_3", Status: RunStatusCompleted, Usage: &Usage{PromptTokens: 50, CompletionTokens: 10, TotalTokens: 60}},
+	}
+
+	var (
+		mu        sync.Mutex
+		stepCalls int
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /threads/thread_123", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Thread{ID: "thread_123", Metadata: Meta{"user": "ada"}})
+	})
+	mux.HandleFunc("GET /threads/thread_123/messages", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ThreadMessageList{Object: "list", Data: []MessageContent{answer, question}})
+	})
+	mux.HandleFunc("GET /threads/thread_123/runs", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, OrderAsc, r.URL.Query().Get("order"))
+
+		page := RunList{Object: "list", Data: runs[:2], LastID: "run_2", HasMore: true}
+		if r.URL.Query().Get("after") == "run_2" {
+			page = RunList{Object: "list", Data: runs[2:], LastID: "run_3"}
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("GET /threads/thread_123/runs/run_1/steps", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, fileSearchContentInclude, r.URL.Query().Get("include[]"))
+
+		mu.Lock()
+		stepCalls++
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(RunSteps{Data: []RunStep{{
+			StepDetails: &StepDetail{
+				Type: "tool_calls",
+				ToolCalls: []ToolCall{{
+					Type: ToolTypeFileSearch,
+					FileSearch: &FileSearchCall{Results: []FileSearchResult{{
+						FileID:   "file-policy",
+						FileName: "policy.pdf",
+						Content:  []FileSearchResultContent{{Type: ContentTypeText, Text: "Refunds take 5 business days."}},
+					}}},
+				}},
+			},
+		}}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	export, err := client.ExportThread(context.Background(), "thread_123")
+	require.NoError(t, err)
+
+	require.Equal(t, "thread_123", export.Thread.ID)
+	require.Equal(t, Meta{"user": "ada"}, export.Thread.Metadata)
+
+	require.Len(t, export.Messages, 2)
+	require.Equal(t, "msg_1", export.Messages[0].ID)
+	require.Empty(t, export.Messages[0].Citations)
+	require.Equal(t, "msg_2", export.Messages[1].ID)
+	require.Equal(t, []ResolvedCitation{{
+		Text:       "【4:0†policy.pdf】",
+		StartIndex: 6,
+		EndIndex:   22,
+		FileID:     "file-policy",
+		FileName:   "policy.pdf",
+		Quote:      "Refunds take 5 business days.",
+	}}, export.Messages[1].Citations)
+	require.Equal(t, 1, stepCalls)
+
+	require.Equal(t, runs, export.Runs)
+	require.Equal(t, Usage{PromptTokens: 150, CompletionTokens: 30, TotalTokens: 180}, export.Usage)
+
+	b, err := json.Marshal(export)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"citations":[{"text":"【4:0†policy.pdf】"`)
+}
+
+func TestClient_ExportThread_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	_, err := client.ExportThread(context.Background(), "thread_123")
+	require.ErrorContains(t, err, "could not get thread")
+}
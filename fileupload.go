@@ -1,49 +1,83 @@
 package openai
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
-	"mime/multipart"
+	"mime"
 	"net/http"
+	"path"
+	"strings"
 	"time"
-
-	"github.com/wiselead-ai/httpclient"
 )
 
-// ListFiles retrieves a list of files that have been uploaded
-func (c *Client) ListFiles(ctx context.Context) (*ListResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/files", nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
+// IsSupportedFileType reports whether files with the extension, with or
+// without its leading dot, can be searched with file_search and so added to
+// vector stores.
+func IsSupportedFileType(ext string) bool {
+	return supportedFileTypes[strings.ToLower(strings.TrimPrefix(ext, "."))]
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+// ListFiles retrieves a page of the files that have been uploaded, only those
+// with the given purpose unless it is empty. Use params.After with the LastID
+// of the previous page to fetch the next one.
+func (c *Client) ListFiles(ctx context.Context, purpose string, params ListParams) (_ *ListResponse, err error) {
+	ctx, op := c.startOperation(ctx, "ListFiles")
+	defer op.end(&err)
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
-	if err != nil {
-		return nil, fmt.Errorf("error sending request: %w", err)
+	query := params.values()
+	if purpose != "" {
+		query.Set("purpose", purpose)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, body)
+	req, err := c.newRequest(ctx, http.MethodGet, "/files"+encodeQuery(query), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	var fileList ListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&fileList); err != nil {
-		return nil, fmt.Errorf("error decoding response: %w", err)
+	if err := c.sendRequest(req, &fileList); err != nil {
+		return nil, err
 	}
 	return &fileList, nil
 }
 
-// UploadFile uploads a file to OpenAI with enhanced logging
-func (c *Client) UploadFile(ctx context.Context, data io.Reader, purpose, ext string) (*FileUploadResponse, error) {
+// UploadFile uploads a file to OpenAI with enhanced logging. The file is
+// named after the upload time with the given extension; use UploadFileNamed
+// to keep its original name, which is the one file_search citations show.
+// The purpose must be one of the FilePurpose constants, and files uploaded for
+// the "assistants" purpose must be of one of the supported file types. The
+// data is streamed as it is sent rather than read into memory first, so the
+// upload is not retried on failure.
+func (c *Client) UploadFile(ctx context.Context, data io.Reader, purpose, ext string) (_ *FileUploadResponse, err error) {
+	ctx, op := c.startOperation(ctx, "UploadFile")
+	defer op.end(&err)
+
+	if ext == "" {
+		return nil, fmt.Errorf("extension is required")
+	}
+	return c.uploadFile(ctx, data, purpose, fmt.Sprintf("data_%d.%s", time.Now().Unix(), ext), ext)
+}
+
+// UploadFileNamed uploads a file under the given name, taking its extension
+// from the name. The purpose and the file type are checked like UploadFile
+// does, and the data is streamed without retries likewise.
+func (c *Client) UploadFileNamed(ctx context.Context, data io.Reader, purpose, filename string) (_ *FileUploadResponse, err error) {
+	ctx, op := c.startOperation(ctx, "UploadFileNamed")
+	defer op.end(&err)
+
+	filename = path.Base(filename)
+	ext := strings.TrimPrefix(path.Ext(filename), ".")
+	if ext == "" {
+		return nil, fmt.Errorf("filename '%s' has no extension", filename)
+	}
+	return c.uploadFile(ctx, data, purpose, filename, ext)
+}
+
+func (c *Client) uploadFile(ctx context.Context, data io.Reader, purpose, filename, ext string) (*FileUploadResponse, error) {
 	if data == nil {
 		return nil, fmt.Errorf("data cannot be nil")
 	}
@@ -52,19 +86,14 @@ func (c *Client) UploadFile(ctx context.Context, data io.Reader, purpose, ext st
 		return nil, fmt.Errorf("purpose is required")
 	}
 
-	if ext == "" {
-		return nil, fmt.Errorf("extension is required")
+	if !filePurposes[purpose] {
+		return nil, fmt.Errorf("purpose '%s' is not supported", purpose)
 	}
 
-	if !supportedFileTypes[ext] {
+	if purpose == FilePurposeAssistants && !IsSupportedFileType(ext) {
 		return nil, fmt.Errorf("extension '%s' is not supported", ext)
 	}
 
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
-
-	filename := fmt.Sprintf("data_%d.%s", time.Now().Unix(), ext)
-
 	if c.logger != nil {
 		c.logger.Info("Uploading file",
 			slog.String("filename", filename),
@@ -72,41 +101,29 @@ func (c *Client) UploadFile(ctx context.Context, data io.Reader, purpose, ext st
 			slog.String("extension", ext))
 	}
 
-	part, err := writer.CreateFormFile("file", filename)
-	if err != nil {
-		return nil, fmt.Errorf("error creating form file: %w", err)
-	}
-
-	if _, err := io.Copy(part, data); err != nil {
-		return nil, fmt.Errorf("error copying data to form file: %w", err)
-	}
-
-	if err := writer.WriteField("purpose", purpose); err != nil {
-		return nil, fmt.Errorf("error writing purpose field: %w", err)
-	}
-
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("error closing multipart writer: %w", err)
-	}
+	body, contentType := streamMultipart(
+		map[string]string{"purpose": purpose},
+		fileField{name: "file", filename: filename, data: data},
+	)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/files", &body)
+	req, err := c.newRequest(ctx, http.MethodPost, "/files", body)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		body.Close()
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Content-Type", contentType)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		responseBody, _ := io.ReadAll(resp.Body)
-		log.Printf("File upload failed. Status: %d, Response: %s", resp.StatusCode, responseBody)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, responseBody)
+		apiErr := newAPIError(resp)
+		log.Printf("File upload failed. Status: %d, Response: %s", apiErr.StatusCode, apiErr.Body)
+		return nil, apiErr
 	}
 
 	var uploadResp FileUploadResponse
@@ -116,28 +133,34 @@ func (c *Client) UploadFile(ctx context.Context, data io.Reader, purpose, ext st
 	return &uploadResp, nil
 }
 
-func (c *Client) GetFileContent(ctx context.Context, fileID string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/files/%s", c.baseURL, fileID),
-		nil,
-	)
+// GetFileContent downloads the content of the file.
+func (c *Client) GetFileContent(ctx context.Context, fileID string) (_ []byte, err error) {
+	ctx, op := c.startOperation(ctx, "GetFileContent")
+	defer op.end(&err)
+
+	content, err := c.GetFileContentWithMeta(ctx, fileID)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, err
 	}
+	return content.Data, nil
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+// GetFileContentWithMeta downloads the content of the file along with its
+// content type and name, to store files such as code interpreter outputs under
+// a fitting name. The name comes from the Content-Disposition header of the
+// download, or from the file's metadata when the header has none.
+func (c *Client) GetFileContentWithMeta(ctx context.Context, fileID string) (_ *FileContent, err error) {
+	ctx, op := c.startOperation(ctx, "GetFileContentWithMeta")
+	defer op.end(&err)
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
+	req, err := c.newRequest(ctx, http.MethodGet, "/files/"+fileID, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving file metadata: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	var fileInfo FileDetails
-	if err := json.NewDecoder(resp.Body).Decode(&fileInfo); err != nil {
-		return nil, fmt.Errorf("error decoding file metadata: %w", err)
+	if err := c.sendRequest(req, &fileInfo); err != nil {
+		return nil, fmt.Errorf("error retrieving file metadata: %w", err)
 	}
 
 	if fileInfo.Purpose == "assistants" {
@@ -145,27 +168,58 @@ func (c *Client) GetFileContent(ctx context.Context, fileID string) ([]byte, err
 		return nil, fmt.Errorf("cannot download files with purpose: assistants")
 	}
 
-	contentReq, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/files/%s/content", c.baseURL, fileID),
-		nil,
-	)
+	contentReq, err := c.newRequest(ctx, http.MethodGet, "/files/"+fileID+"/content", nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating content request: %w", err)
+		return nil, err
 	}
 
-	contentReq.Header.Set("Authorization", "Bearer "+c.apiKey)
-
-	contentResp, err := httpclient.DoWithRetry(c.httpClient, contentReq)
+	contentResp, err := c.doWithRetry(contentReq)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving file content: %w", err)
 	}
 	defer contentResp.Body.Close()
 
+	if contentResp.StatusCode != http.StatusOK {
+		return nil, newAPIError(contentResp)
+	}
+
 	content, err := io.ReadAll(contentResp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
-	return content, nil
+
+	filename := fileInfo.Filename
+	if _, params, err := mime.ParseMediaType(contentResp.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		filename = params["filename"]
+	}
+	return &FileContent{
+		Data:        content,
+		ContentType: contentResp.Header.Get("Content-Type"),
+		Filename:    filename,
+	}, nil
+}
+
+// DeleteFile deletes an uploaded file, freeing its storage.
+func (c *Client) DeleteFile(ctx context.Context, fileID string) (err error) {
+	ctx, op := c.startOperation(ctx, "DeleteFile")
+	defer op.end(&err)
+
+	if fileID == "" {
+		return fmt.Errorf("file ID is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodDelete, "/files/"+fileID, nil)
+	if err != nil {
+		return err
+	}
+
+	var status DeletionStatus
+	if err := c.sendRequest(req, &status); err != nil {
+		return err
+	}
+
+	if !status.Deleted {
+		return fmt.Errorf("file '%s' was not deleted", fileID)
+	}
+	return nil
 }
@@ -4,19 +4,51 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/require"
 )
 
+func TestIsSupportedFileType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ext  string
+		want bool
+	}{
+		{ext: "pdf", want: true},
+		{ext: ".docx", want: true},
+		{ext: "PY", want: true},
+		{ext: ".html", want: true},
+		{ext: "xlsx", want: false},
+		{ext: "jsonl", want: false},
+		{ext: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ext, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, IsSupportedFileType(tt.ext))
+		})
+	}
+}
+
 func TestClient_ListFiles(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name           string
+		purpose        string
+		params         ListParams
+		expectedQuery  string
 		serverResponse *ListResponse
 		serverStatus   int
 		expectError    bool
@@ -25,7 +57,31 @@ func TestClient_ListFiles(t *testing.T) {
 			name: "success",
 			serverResponse: &ListResponse{
 				Object: "list",
-				Data:   []any{map[string]any{"id": "file-123"}},
+				Data:   []FileDetails{{ID: "file-123", Object: "file", Bytes: 120, Purpose: FilePurposeAssistants, Filename: "notes.txt"}},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "filtered by purpose",
+			purpose:       FilePurposeFineTune,
+			params:        ListParams{Limit: 10},
+			expectedQuery: "limit=10&purpose=fine-tune",
+			serverResponse: &ListResponse{
+				Object: "list",
+				Data:   []FileDetails{{ID: "file-125", Purpose: FilePurposeFineTune}},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "paginated",
+			params:        ListParams{Limit: 2, Order: OrderAsc, After: "file-122"},
+			expectedQuery: "after=file-122&limit=2&order=asc",
+			serverResponse: &ListResponse{
+				Object:  "list",
+				Data:    []FileDetails{{ID: "file-123"}, {ID: "file-124"}},
+				FirstID: "file-123",
+				LastID:  "file-124",
+				HasMore: true,
 			},
 			serverStatus: http.StatusOK,
 		},
@@ -42,6 +98,7 @@ func TestClient_ListFiles(t *testing.T) {
 
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				require.Equal(t, "/files", r.URL.Path)
+				require.Equal(t, tt.expectedQuery, r.URL.RawQuery)
 				w.WriteHeader(tt.serverStatus)
 				if tt.serverResponse != nil {
 					json.NewEncoder(w).Encode(tt.serverResponse)
@@ -53,9 +110,10 @@ func TestClient_ListFiles(t *testing.T) {
 				httpClient: server.Client(),
 				baseURL:    server.URL,
 				apiKey:     "test-key",
+				retrySleep: withoutRetryDelay(nil, nil),
 			}
 
-			resp, err := client.ListFiles(context.Background())
+			resp, err := client.ListFiles(context.Background(), tt.purpose, tt.params)
 			if tt.expectError {
 				require.Error(t, err)
 				return
@@ -72,6 +130,7 @@ func TestClient_UploadFile(t *testing.T) {
 	tests := []struct {
 		name           string
 		purpose        string
+		ext            string
 		data           []byte
 		serverResponse *FileUploadResponse
 		serverStatus   int
@@ -80,6 +139,7 @@ func TestClient_UploadFile(t *testing.T) {
 		{
 			name:    "success",
 			purpose: "fine-tune",
+			ext:     "txt",
 			data:    []byte(`test file content`),
 			serverResponse: &FileUploadResponse{
 				ID:     "file-123",
@@ -90,6 +150,7 @@ func TestClient_UploadFile(t *testing.T) {
 		{
 			name:         "bad request",
 			purpose:      "fine-tune",
+			ext:          "txt",
 			data:         []byte(`test file content`),
 			serverStatus: http.StatusBadRequest,
 			expectError:  true,
@@ -97,6 +158,7 @@ func TestClient_UploadFile(t *testing.T) {
 		{
 			name:         "empty file",
 			purpose:      "fine-tune",
+			ext:          "txt",
 			data:         []byte{},
 			serverStatus: http.StatusBadRequest,
 			expectError:  true,
@@ -104,6 +166,7 @@ func TestClient_UploadFile(t *testing.T) {
 		{
 			name:    "large file",
 			purpose: "fine-tune",
+			ext:     "txt",
 			data:    bytes.Repeat([]byte("x"), 1024*1024), // 1MB file
 			serverResponse: &FileUploadResponse{
 				ID:     "file-large",
@@ -111,6 +174,49 @@ func TestClient_UploadFile(t *testing.T) {
 			},
 			serverStatus: http.StatusOK,
 		},
+		{
+			name:    "fine-tune jsonl",
+			purpose: FilePurposeFineTune,
+			ext:     "jsonl",
+			data:    []byte(`{"messages":[]}`),
+			serverResponse: &FileUploadResponse{
+				ID:     "file-jsonl",
+				Object: "file",
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:        "unsupported assistants file type",
+			purpose:     FilePurposeAssistants,
+			ext:         "jsonl",
+			data:        []byte(`{"messages":[]}`),
+			expectError: true,
+		},
+		{
+			name:        "unsupported purpose",
+			purpose:     "finetuning",
+			ext:         "jsonl",
+			data:        []byte(`{"messages":[]}`),
+			expectError: true,
+		},
+		{
+			name:    "user data",
+			purpose: FilePurposeUserData,
+			ext:     "pdf",
+			data:    []byte("%PDF-1.4"),
+			serverResponse: &FileUploadResponse{
+				ID:      "file-user",
+				Object:  "file",
+				Purpose: FilePurposeUserData,
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:        "missing extension",
+			purpose:     FilePurposeFineTune,
+			data:        []byte(`test file content`),
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -147,7 +253,7 @@ func TestClient_UploadFile(t *testing.T) {
 				context.Background(),
 				bytes.NewReader(tt.data),
 				tt.purpose,
-				"txt",
+				tt.ext,
 			)
 			if tt.expectError {
 				require.Error(t, err)
@@ -160,6 +266,27 @@ func TestClient_UploadFile(t *testing.T) {
 	}
 }
 
+func TestClient_UploadFile_ReadError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		apiKey:     "test-key",
+	}
+
+	readErr := errors.New("disk failure")
+	data := io.MultiReader(strings.NewReader(`{"messages":[]}`), iotest.ErrReader(readErr))
+	_, err := client.UploadFile(context.Background(), data, FilePurposeFineTune, "jsonl")
+	require.ErrorIs(t, err, readErr)
+}
+
 func TestClient_GetFileContent(t *testing.T) {
 	t.Parallel()
 
@@ -230,3 +357,196 @@ func TestClient_GetFileContent(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetFileContentWithMeta(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		wantContent *FileContent
+	}{
+		{
+			name: "content disposition filename",
+			headers: map[string]string{
+				"Content-Type":        "image/png",
+				"Content-Disposition": `attachment; filename="plot.png"`,
+			},
+			wantContent: &FileContent{Data: []byte("file data"), ContentType: "image/png", Filename: "plot.png"},
+		},
+		{
+			name:        "filename from metadata",
+			headers:     map[string]string{"Content-Type": "text/csv"},
+			wantContent: &FileContent{Data: []byte("file data"), ContentType: "text/csv", Filename: "/mnt/data/out.csv"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/files/file-123":
+					json.NewEncoder(w).Encode(FileDetails{ID: "file-123", Filename: "/mnt/data/out.csv", Purpose: "assistants_output"})
+				case "/files/file-123/content":
+					for key, value := range tt.headers {
+						w.Header().Set(key, value)
+					}
+					w.Write([]byte("file data"))
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient: server.Client(),
+				baseURL:    server.URL,
+				apiKey:     "test-key",
+			}
+
+			content, err := client.GetFileContentWithMeta(context.Background(), "file-123")
+			require.NoError(t, err)
+			require.Equal(t, tt.wantContent, content)
+		})
+	}
+}
+
+func TestClient_DeleteFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		fileID         string
+		serverResponse *DeletionStatus
+		serverStatus   int
+		expectError    bool
+	}{
+		{
+			name:           "successful deletion",
+			fileID:         "file-123",
+			serverResponse: &DeletionStatus{ID: "file-123", Object: "file", Deleted: true},
+			serverStatus:   http.StatusOK,
+		},
+		{
+			name:           "not deleted",
+			fileID:         "file-123",
+			serverResponse: &DeletionStatus{ID: "file-123", Object: "file"},
+			serverStatus:   http.StatusOK,
+			expectError:    true,
+		},
+		{
+			name:         "not found",
+			fileID:       "file-123",
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+		{
+			name:        "empty file ID",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/files/file-123", r.URL.Path)
+				require.Equal(t, http.MethodDelete, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient: server.Client(),
+				baseURL:    server.URL,
+				apiKey:     "test-key",
+			}
+
+			err := client.DeleteFile(context.Background(), tt.fileID)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_UploadFileNamed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		purpose      string
+		filename     string
+		wantFilename string
+		expectError  bool
+	}{
+		{
+			name:         "extension from filename",
+			purpose:      FilePurposeFineTune,
+			filename:     "training.jsonl",
+			wantFilename: "training.jsonl",
+		},
+		{
+			name:         "directories are dropped",
+			purpose:      FilePurposeAssistants,
+			filename:     "docs/manual.pdf",
+			wantFilename: "manual.pdf",
+		},
+		{
+			name:        "no extension",
+			purpose:     FilePurposeFineTune,
+			filename:    "training",
+			expectError: true,
+		},
+		{
+			name:        "unsupported assistants file type",
+			purpose:     FilePurposeAssistants,
+			filename:    "training.jsonl",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseMultipartForm(32<<20))
+
+				_, header, err := r.FormFile("file")
+				require.NoError(t, err)
+				require.Equal(t, tt.wantFilename, header.Filename)
+				require.Equal(t, tt.purpose, r.FormValue("purpose"))
+
+				json.NewEncoder(w).Encode(FileUploadResponse{ID: "file-123", Filename: header.Filename})
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient: server.Client(),
+				baseURL:    server.URL,
+				apiKey:     "test-key",
+			}
+
+			resp, err := client.UploadFileNamed(context.Background(), bytes.NewReader([]byte("content")), tt.purpose, tt.filename)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantFilename, resp.Filename)
+		})
+	}
+}
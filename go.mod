@@ -2,10 +2,7 @@ module github.com/wiselead-ai/openai
 
 go 1.23.4
 
-require (
-	github.com/stretchr/testify v1.10.0
-	github.com/wiselead-ai/httpclient v0.0.0-20250113160640-93a489d7e048
-)
+require github.com/stretchr/testify v1.10.0
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
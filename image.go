@@ -0,0 +1,39 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CreateImage generates images from the prompt. Unset fields use the API's
+// defaults.
+func (c *Client) CreateImage(ctx context.Context, in ImageRequest) (_ *ImageResponse, err error) {
+	ctx, op := c.startOperation(ctx, "CreateImage")
+	defer op.end(&err)
+
+	if in.Prompt == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+
+	if in.N < 0 {
+		return nil, fmt.Errorf("number of images %d cannot be negative", in.N)
+	}
+
+	switch in.ResponseFormat {
+	case "", ImageResponseFormatURL, ImageResponseFormatB64JSON:
+	default:
+		return nil, fmt.Errorf("unsupported response format '%s'", in.ResponseFormat)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/images/generations", in)
+	if err != nil {
+		return nil, err
+	}
+
+	var images ImageResponse
+	if err := c.sendRequest(req, &images); err != nil {
+		return nil, err
+	}
+	return &images, nil
+}
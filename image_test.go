@@ -0,0 +1,109 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateImage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        ImageRequest
+		wantBody     string
+		serverBody   string
+		serverStatus int
+		expected     *ImageResponse
+		expectError  bool
+	}{
+		{
+			name: "url",
+			input: ImageRequest{
+				Prompt:  "A lighthouse at dawn",
+				Model:   "dall-e-3",
+				Size:    "1024x1024",
+				Quality: "hd",
+				N:       1,
+			},
+			wantBody:     `{"prompt":"A lighthouse at dawn","model":"dall-e-3","size":"1024x1024","quality":"hd","n":1}`,
+			serverBody:   `{"created":1700000000,"data":[{"url":"https://example.com/a.png","revised_prompt":"A tall lighthouse at dawn"}]}`,
+			serverStatus: http.StatusOK,
+			expected: &ImageResponse{
+				Created: 1700000000,
+				Data:    []ImageData{{URL: "https://example.com/a.png", RevisedPrompt: "A tall lighthouse at dawn"}},
+			},
+		},
+		{
+			name: "base64 decoded",
+			input: ImageRequest{
+				Prompt:         "A lighthouse at dawn",
+				ResponseFormat: ImageResponseFormatB64JSON,
+			},
+			wantBody:     `{"prompt":"A lighthouse at dawn","response_format":"b64_json"}`,
+			serverBody:   `{"created":1700000000,"data":[{"b64_json":"iVBORw=="}]}`,
+			serverStatus: http.StatusOK,
+			expected: &ImageResponse{
+				Created: 1700000000,
+				Data:    []ImageData{{B64JSON: []byte{0x89, 'P', 'N', 'G'}}},
+			},
+		},
+		{
+			name:        "missing prompt",
+			input:       ImageRequest{Model: "dall-e-3"},
+			expectError: true,
+		},
+		{
+			name:        "unsupported response format",
+			input:       ImageRequest{Prompt: "A lighthouse", ResponseFormat: "png"},
+			expectError: true,
+		},
+		{
+			name:         "rejected prompt",
+			input:        ImageRequest{Prompt: "A lighthouse"},
+			wantBody:     `{"prompt":"A lighthouse"}`,
+			serverBody:   `{"error":{"message":"Your request was rejected by the safety system"}}`,
+			serverStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/images/generations", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.wantBody, string(body))
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverBody))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.CreateImage(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// messagePageSize is the number of messages StreamMessages requests per page,
+// the maximum the API allows.
+const messagePageSize = 100
+
+// NewTextMessage returns a message with plain text content.
+func NewTextMessage(role, text string) ThreadMessage {
+	return ThreadMessage{Role: role, Content: text}
+}
+
+// NewTextPart returns a text content part.
+func NewTextPart(text string) MessageContentPart {
+	return MessageContentPart{Type: ContentTypeText, Text: text}
+}
+
+// NewImageURLPart returns a content part showing the image at url. An empty
+// detail leaves the choice to the API.
+func NewImageURLPart(url, detail string) MessageContentPart {
+	return MessageContentPart{Type: ContentTypeImageURL, ImageURL: &ImageURL{URL: url, Detail: detail}}
+}
+
+// NewImageFilePart returns a content part showing an uploaded image file. An
+// empty detail leaves the choice to the API.
+func NewImageFilePart(fileID, detail string) MessageContentPart {
+	return MessageContentPart{Type: ContentTypeImageFile, ImageFile: &ImageFile{FileID: fileID, Detail: detail}}
+}
+
+// MarshalJSON encodes the message content as a plain string, or as an array of
+// content parts when ContentParts is set.
+func (m ThreadMessage) MarshalJSON() ([]byte, error) {
+	type message ThreadMessage
+	out := struct {
+		message
+		Content any `json:"content"`
+	}{
+		message: message(m),
+		Content: m.Content,
+	}
+	if len(m.ContentParts) > 0 {
+		out.Content = m.ContentParts
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a message whose content is either a plain string or an
+// array of content parts.
+func (m *ThreadMessage) UnmarshalJSON(b []byte) error {
+	type message ThreadMessage
+	var in struct {
+		message
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	*m = ThreadMessage(in.message)
+	if len(in.Content) == 0 || string(in.Content) == "null" {
+		return nil
+	}
+	if in.Content[0] == '"' {
+		return json.Unmarshal(in.Content, &m.Content)
+	}
+	return json.Unmarshal(in.Content, &m.ContentParts)
+}
+
+func validateMessage(m ThreadMessage) error {
+	for i, att := range m.Attachments {
+		if att.FileID == "" {
+			return fmt.Errorf("attachment %d: file ID is required", i)
+		}
+		if len(att.Tools) == 0 {
+			return fmt.Errorf("attachment %d: at least one tool is required", i)
+		}
+		for _, tool := range att.Tools {
+			switch tool.Type {
+			case ToolTypeFileSearch, ToolTypeCodeInterpreter:
+			default:
+				return fmt.Errorf("attachment %d: tool '%s' cannot use attachments", i, tool.Type)
+			}
+		}
+	}
+
+	if len(m.ContentParts) == 0 {
+		if m.Content == "" {
+			return fmt.Errorf("message content is required")
+		}
+		return nil
+	}
+
+	for i, part := range m.ContentParts {
+		switch part.Type {
+		case ContentTypeText:
+			if part.Text == "" {
+				return fmt.Errorf("content part %d: text is required", i)
+			}
+		case ContentTypeImageURL:
+			if part.ImageURL == nil || part.ImageURL.URL == "" {
+				return fmt.Errorf("content part %d: image URL is required", i)
+			}
+			if err := validateImageDetail(part.ImageURL.Detail); err != nil {
+				return fmt.Errorf("content part %d: %w", i, err)
+			}
+		case ContentTypeImageFile:
+			if part.ImageFile == nil || part.ImageFile.FileID == "" {
+				return fmt.Errorf("content part %d: image file ID is required", i)
+			}
+			if err := validateImageDetail(part.ImageFile.Detail); err != nil {
+				return fmt.Errorf("content part %d: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("content part %d: unknown type '%s'", i, part.Type)
+		}
+	}
+	return nil
+}
+
+func validateImageDetail(detail string) error {
+	switch detail {
+	case "", ImageDetailAuto, ImageDetailLow, ImageDetailHigh:
+		return nil
+	default:
+		return fmt.Errorf("unknown image detail '%s'", detail)
+	}
+}
+
+// StreamMessages calls fn with every message of the thread, newest first. The
+// list is fetched page by page and each page is decoded one message at a time,
+// so memory use stays flat regardless of the size of the thread. It stops at
+// the first error returned by fn and returns that error as is.
+func (c *Client) StreamMessages(ctx context.Context, threadID string, fn func(MessageContent) error) (err error) {
+	ctx, op := c.startOperation(ctx, "StreamMessages")
+	defer op.end(&err)
+
+	if threadID == "" {
+		return fmt.Errorf("thread ID is required")
+	}
+
+	params := ListParams{Limit: messagePageSize}
+	for {
+		lastID, hasMore, err := c.streamMessagePage(ctx, threadID, params, fn)
+		if err != nil {
+			return err
+		}
+
+		if !hasMore || lastID == "" {
+			return nil
+		}
+		params.After = lastID
+	}
+}
+
+// GetAllMessages retrieves every message of the thread, newest first,
+// following the pages of the list until there are no more.
+func (c *Client) GetAllMessages(ctx context.Context, threadID string) (_ []MessageContent, err error) {
+	ctx, op := c.startOperation(ctx, "GetAllMessages")
+	defer op.end(&err)
+
+	var messages []MessageContent
+	err = c.StreamMessages(ctx, threadID, func(msg MessageContent) error {
+		messages = append(messages, msg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (c *Client) streamMessagePage(
+	ctx context.Context,
+	threadID string,
+	params ListParams,
+	fn func(MessageContent) error,
+) (string, bool, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/messages%s", threadID, params.query()), nil)
+	if err != nil {
+		return "", false, err
+	}
+
+	resp, err := c.doWithRetry(req)
+	if err != nil {
+		return "", false, fmt.Errorf("could not send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", false, newAPIError(resp)
+	}
+	return decodeMessagePage(resp.Body, fn)
+}
+
+// decodeMessagePage walks a message list object token by token, calling fn for
+// each element of its data array as soon as it is decoded. It returns the ID
+// of the last message and the has_more flag of the page.
+func decodeMessagePage(r io.Reader, fn func(MessageContent) error) (string, bool, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return "", false, err
+	}
+
+	var (
+		lastID  string
+		hasMore bool
+	)
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return "", false, fmt.Errorf("could not decode response: %w", err)
+		}
+
+		switch key {
+		case "data":
+			if err := expectDelim(dec, '['); err != nil {
+				return "", false, err
+			}
+			for dec.More() {
+				var msg MessageContent
+				if err := dec.Decode(&msg); err != nil {
+					return "", false, fmt.Errorf("could not decode message: %w", err)
+				}
+				lastID = msg.ID
+				if err := fn(msg); err != nil {
+					return "", false, err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return "", false, err
+			}
+		case "has_more":
+			if err := dec.Decode(&hasMore); err != nil {
+				return "", false, fmt.Errorf("could not decode response: %w", err)
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return "", false, fmt.Errorf("could not decode response: %w", err)
+			}
+		}
+	}
+	return lastID, hasMore, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	if tok != want {
+		return fmt.Errorf("could not decode response: expected '%s', got %v", want, tok)
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadMessage_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message ThreadMessage
+		want    string
+	}{
+		{
+			name:    "plain text",
+			message: ThreadMessage{Role: RoleUser, Content: "Hello"},
+			want:    `{"role":"user","content":"Hello"}`,
+		},
+		{
+			name: "image with detail",
+			message: ThreadMessage{
+				Role: RoleUser,
+				ContentParts: []MessageContentPart{
+					{Type: ContentTypeText, Text: "What is in this picture?"},
+					{Type: ContentTypeImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png", Detail: ImageDetailLow}},
+				},
+			},
+			want: `{"role":"user","content":[` +
+				`{"type":"text","text":"What is in this picture?"},` +
+				`{"type":"image_url","image_url":{"url":"https://example.com/cat.png","detail":"low"}}]}`,
+		},
+		{
+			name: "uploaded image",
+			message: ThreadMessage{
+				Role: RoleUser,
+				ContentParts: []MessageContentPart{
+					NewTextPart("What is wrong on this screen?"),
+					NewImageFilePart("file-abc", ImageDetailHigh),
+				},
+			},
+			want: `{"role":"user","content":[` +
+				`{"type":"text","text":"What is wrong on this screen?"},` +
+				`{"type":"image_file","image_file":{"file_id":"file-abc","detail":"high"}}]}`,
+		},
+		{
+			name: "attachments",
+			message: ThreadMessage{
+				Role:    RoleUser,
+				Content: "Summarize the report",
+				Attachments: []MessageAttachment{
+					{FileID: "file-pdf", Tools: []Tool{{Type: ToolTypeFileSearch}}},
+				},
+			},
+			want: `{"role":"user","content":"Summarize the report",` +
+				`"attachments":[{"file_id":"file-pdf","tools":[{"type":"file_search"}]}]}`,
+		},
+		{
+			name:    "text constructor",
+			message: NewTextMessage(RoleUser, "Hello"),
+			want:    `{"role":"user","content":"Hello"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(tt.message)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(b))
+
+			var got ThreadMessage
+			require.NoError(t, json.Unmarshal(b, &got))
+			require.Equal(t, tt.message, got)
+		})
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		message     ThreadMessage
+		expectError bool
+	}{
+		{
+			name:    "plain text",
+			message: ThreadMessage{Role: RoleUser, Content: "Hello"},
+		},
+		{
+			name:        "empty content",
+			message:     ThreadMessage{Role: RoleUser},
+			expectError: true,
+		},
+		{
+			name: "image without detail",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				{Type: ContentTypeImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png"}},
+			}},
+		},
+		{
+			name: "image without URL",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				{Type: ContentTypeImageURL},
+			}},
+			expectError: true,
+		},
+		{
+			name: "unknown detail",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				{Type: ContentTypeImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png", Detail: "medium"}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "image file",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				NewImageURLPart("https://example.com/cat.png", ImageDetailAuto),
+				NewImageFilePart("file-abc", ""),
+			}},
+		},
+		{
+			name: "attachment",
+			message: ThreadMessage{Role: RoleUser, Content: "Plot this", Attachments: []MessageAttachment{
+				{FileID: "file-csv", Tools: []Tool{{Type: ToolTypeCodeInterpreter}, {Type: ToolTypeFileSearch}}},
+			}},
+		},
+		{
+			name: "attachment without file ID",
+			message: ThreadMessage{Role: RoleUser, Content: "Plot this", Attachments: []MessageAttachment{
+				{Tools: []Tool{{Type: ToolTypeCodeInterpreter}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "attachment without tools",
+			message: ThreadMessage{Role: RoleUser, Content: "Plot this", Attachments: []MessageAttachment{
+				{FileID: "file-csv"},
+			}},
+			expectError: true,
+		},
+		{
+			name: "attachment for function tool",
+			message: ThreadMessage{Role: RoleUser, Content: "Plot this", Attachments: []MessageAttachment{
+				{FileID: "file-csv", Tools: []Tool{{Type: ToolTypeFunction}}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "image file without ID",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				{Type: ContentTypeImageFile, ImageFile: &ImageFile{}},
+			}},
+			expectError: true,
+		},
+		{
+			name: "image file with unknown detail",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				NewImageFilePart("file-abc", "medium"),
+			}},
+			expectError: true,
+		},
+		{
+			name: "empty text part",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				{Type: ContentTypeText},
+			}},
+			expectError: true,
+		},
+		{
+			name: "unknown part type",
+			message: ThreadMessage{Role: RoleUser, ContentParts: []MessageContentPart{
+				{Type: "video"},
+			}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateMessage(tt.message)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_StreamMessages(t *testing.T) {
+	t.Parallel()
+
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name         string
+		pages        map[string]string // Response body by "after" cursor
+		serverStatus int
+		stopAt       string
+		wantIDs      []string
+		expectErrIs  error
+		expectError  bool
+	}{
+		{
+			name: "single page",
+			pages: map[string]string{
+				"": `{"object":"list","data":[{"id":"msg_3","role":"assistant"},{"id":"msg_2","role":"user"}],` +
+					`"first_id":"msg_3","last_id":"msg_2","has_more":false}`,
+			},
+			wantIDs: []string{"msg_3", "msg_2"},
+		},
+		{
+			name: "multiple pages",
+			pages: map[string]string{
+				"":      `{"object":"list","data":[{"id":"msg_3"},{"id":"msg_2"}],"has_more":true}`,
+				"msg_2": `{"object":"list","data":[{"id":"msg_1"}],"has_more":false}`,
+			},
+			wantIDs: []string{"msg_3", "msg_2", "msg_1"},
+		},
+		{
+			name: "empty thread",
+			pages: map[string]string{
+				"": `{"object":"list","data":[],"has_more":false}`,
+			},
+		},
+		{
+			name: "callback error stops streaming",
+			pages: map[string]string{
+				"":      `{"object":"list","data":[{"id":"msg_3"},{"id":"msg_2"}],"has_more":true}`,
+				"msg_2": `{"object":"list","data":[{"id":"msg_1"}],"has_more":false}`,
+			},
+			stopAt:      "msg_2",
+			wantIDs:     []string{"msg_3", "msg_2"},
+			expectErrIs: errStop,
+		},
+		{
+			name: "malformed response",
+			pages: map[string]string{
+				"": `{"object":"list","data":{"id":"msg_1"}}`,
+			},
+			expectError: true,
+		},
+		{
+			name:         "not found",
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/thread_123/messages", r.URL.Path)
+				require.Equal(t, "100", r.URL.Query().Get("limit"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				if tt.serverStatus != 0 {
+					w.WriteHeader(tt.serverStatus)
+					return
+				}
+
+				page, ok := tt.pages[r.URL.Query().Get("after")]
+				require.True(t, ok, "unexpected cursor %q", r.URL.Query().Get("after"))
+				fmt.Fprint(w, page)
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			var gotIDs []string
+			err := client.StreamMessages(context.Background(), "thread_123", func(msg MessageContent) error {
+				gotIDs = append(gotIDs, msg.ID)
+				if msg.ID == tt.stopAt {
+					return errStop
+				}
+				return nil
+			})
+			require.Equal(t, tt.wantIDs, gotIDs)
+
+			if tt.expectErrIs != nil {
+				require.ErrorIs(t, err, tt.expectErrIs)
+				return
+			}
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_GetAllMessages(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]ThreadMessageList{
+		"":      {Object: "list", Data: []MessageContent{{ID: "msg_3"}, {ID: "msg_2"}}, LastID: "msg_2", HasMore: true},
+		"msg_2": {Object: "list", Data: []MessageContent{{ID: "msg_1"}}, LastID: "msg_1"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/threads/thread_123/messages", r.URL.Path)
+
+		page, ok := pages[r.URL.Query().Get("after")]
+		require.True(t, ok)
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	messages, err := client.GetAllMessages(context.Background(), "thread_123")
+	require.NoError(t, err)
+	require.Equal(t, []MessageContent{{ID: "msg_3"}, {ID: "msg_2"}, {ID: "msg_1"}}, messages)
+}
@@ -3,13 +3,17 @@ package openai
 import (
 	"encoding/json"
 	"io"
+	"time"
 )
 
 const (
 	DefaultAssistTemp  float64 = 0.2
 	DefaultAssistModel Model   = "gpt-4-turbo"
 
-	RoleUser = "user"
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
 
 	RunStatusQueued         = "queued"
 	RunStatusInProgress     = "in_progress"
@@ -20,12 +24,84 @@ const (
 	RunStatusExpired        = "expired"
 	RunStatusRequiresAction = "requires_action"
 	RunStatusPending        = "pending"
+	RunStatusIncomplete     = "incomplete"
+
+	// Reason reported when a run was blocked by content filtering
+	ContentFilterReason = "content_filter"
+
+	// Code of the last error of a run that failed on a transient server
+	// error, which is safe to run again
+	RunErrorCodeServerError = "server_error"
 
 	// Tool types
 	ToolTypeFunction        = "function"
 	ToolTypeCodeInterpreter = "code_interpreter"
 	ToolTypeFileSearch      = "file_search"
 
+	// Message content part types
+	ContentTypeText      = "text"
+	ContentTypeImageURL  = "image_url"
+	ContentTypeImageFile = "image_file"
+
+	// Image detail levels, which control the vision token cost of an image
+	ImageDetailAuto = "auto"
+	ImageDetailLow  = "low"
+	ImageDetailHigh = "high"
+
+	// Response formats of assistants and runs
+	ResponseFormatAuto       = "auto"
+	ResponseFormatText       = "text"
+	ResponseFormatJSONObject = "json_object"
+	ResponseFormatJSONSchema = "json_schema"
+
+	// Vector store file statuses
+	VectorStoreFileStatusInProgress = "in_progress"
+	VectorStoreFileStatusCompleted  = "completed"
+	VectorStoreFileStatusCancelled  = "cancelled"
+	VectorStoreFileStatusFailed     = "failed"
+
+	// Sort orders for list endpoints
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+
+	// Stream events, see https://platform.openai.com/docs/api-reference/assistants-streaming/events
+	StreamEventMessageDelta = "thread.message.delta"
+	StreamEventRunStepDelta = "thread.run.step.delta"
+	StreamEventRunCompleted = "thread.run.completed"
+	StreamEventError        = "error"
+	StreamEventDone         = "done"
+
+	// Chat completion stream events, named by the client as the API sends
+	// them unnamed
+	StreamEventChatCompletionChunk = "chat.completion.chunk"
+	StreamEventUsage               = "usage"
+
+	// Response formats of generated images
+	ImageResponseFormatURL     = "url"
+	ImageResponseFormatB64JSON = "b64_json"
+
+	// Response formats of audio transcriptions
+	TranscriptionFormatText        = "text"
+	TranscriptionFormatJSON        = "json"
+	TranscriptionFormatVerboseJSON = "verbose_json"
+	TranscriptionFormatSRT         = "srt"
+	TranscriptionFormatVTT         = "vtt"
+
+	// Embedding encoding formats
+	EncodingFormatFloat  = "float"
+	EncodingFormatBase64 = "base64"
+
+	// Truncation strategies for runs
+	TruncationAuto         = "auto"
+	TruncationLastMessages = "last_messages"
+
+	// File purposes
+	FilePurposeAssistants = "assistants"
+	FilePurposeFineTune   = "fine-tune"
+	FilePurposeBatch      = "batch"
+	FilePurposeVision     = "vision"
+	FilePurposeUserData   = "user_data"
+
 	// Supported file types for vector stores and file search
 	FileTypePDF  = "pdf"
 	FileTypeTXT  = "txt"
@@ -33,11 +109,39 @@ const (
 	FileTypeMD   = "md"
 )
 
+// filePurposes are the purposes files can be uploaded for.
+var filePurposes = map[string]bool{
+	FilePurposeAssistants: true,
+	FilePurposeFineTune:   true,
+	FilePurposeBatch:      true,
+	FilePurposeVision:     true,
+	FilePurposeUserData:   true,
+}
+
+// supportedFileTypes are the file types file_search accepts, see
+// https://platform.openai.com/docs/assistants/tools/file-search/supported-files
 var supportedFileTypes = map[string]bool{
-	FileTypePDF:  true,
-	FileTypeTXT:  true,
+	"c":          true,
+	"cpp":        true,
+	"cs":         true,
+	"css":        true,
+	"doc":        true,
+	"docx":       true,
+	"go":         true,
+	"html":       true,
+	"java":       true,
+	"js":         true,
 	FileTypeJSON: true,
 	FileTypeMD:   true,
+	FileTypePDF:  true,
+	"php":        true,
+	"pptx":       true,
+	"py":         true,
+	"rb":         true,
+	"sh":         true,
+	"tex":        true,
+	"ts":         true,
+	FileTypeTXT:  true,
 }
 
 type (
@@ -46,40 +150,108 @@ type (
 	// Common types
 	Meta map[string]any
 
+	// ListParams controls the pagination of list endpoints. Zero fields are
+	// left out of the query so the API defaults apply.
+	ListParams struct {
+		Limit  int
+		Order  string
+		After  string
+		Before string
+	}
+
+	DeletionStatus struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		Deleted bool   `json:"deleted"`
+	}
+
 	// Assistant
 	// https://platform.openai.com/docs/api-reference/assistants/createAssistant
 
 	CreateAssistantInput struct {
-		Metadata      Meta          `json:"metadata,omitempty"`
-		Name          string        `json:"name"`
-		Description   string        `json:"description"`
-		Model         Model         `json:"model"`
-		Instructions  string        `json:"instructions"`
-		Tools         []Tool        `json:"tools"`
-		ToolResources ToolResources `json:"tool_resources,omitempty"`
-		Temperature   *float64      `json:"temperature,omitempty"`
+		Metadata       Meta            `json:"metadata,omitempty"`
+		Name           string          `json:"name"`
+		Description    string          `json:"description"`
+		Model          Model           `json:"model"`
+		Instructions   string          `json:"instructions"`
+		Tools          []Tool          `json:"tools"`
+		ToolResources  ToolResources   `json:"tool_resources,omitempty"`
+		Temperature    *float64        `json:"temperature,omitempty"`
+		TopP           *float64        `json:"top_p,omitempty"`
+		ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	}
+
+	// RAGAssistantInput describes an assistant answering from files, as
+	// created by CreateRAGAssistant.
+	RAGAssistantInput struct {
+		// Assistant configures the assistant. The file_search tool and the
+		// vector store holding the files are added to it.
+		Assistant CreateAssistantInput
+		// Files are uploaded for the assistant, in addition to the already
+		// uploaded FileIDs.
+		Files   []RAGFile
+		FileIDs []string
+		// VectorStoreName defaults to the name of the assistant.
+		VectorStoreName string
+		// WaitTimeout bounds the wait for the files to be indexed, five
+		// minutes by default.
+		WaitTimeout time.Duration
+	}
+
+	// RAGFile is a file to upload, named with its extension.
+	RAGFile struct {
+		Name string
+		Data io.Reader
+	}
+
+	AssistantList struct {
+		Object  string      `json:"object"`
+		Data    []Assistant `json:"data"`
+		FirstID string      `json:"first_id"`
+		LastID  string      `json:"last_id"`
+		HasMore bool        `json:"has_more"`
 	}
 
 	ModifyAssistantInput struct {
-		Description   string        `json:"description,omitempty"`
-		Instructions  string        `json:"instructions,omitempty"`
-		Tools         []Tool        `json:"tools,omitempty"`
-		ToolResources ToolResources `json:"tool_resources,omitempty"`
-		Metadata      Meta          `json:"metadata,omitempty"`
-		Temperature   *float64      `json:"temperature,omitempty"`
+		Description    string          `json:"description,omitempty"`
+		Instructions   string          `json:"instructions,omitempty"`
+		Tools          []Tool          `json:"tools,omitempty"`
+		ToolResources  ToolResources   `json:"tool_resources,omitempty"`
+		Metadata       Meta            `json:"metadata,omitempty"`
+		Temperature    *float64        `json:"temperature,omitempty"`
+		TopP           *float64        `json:"top_p,omitempty"`
+		ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 	}
 
 	Assistant struct {
-		ID           string   `json:"id"`
-		Object       string   `json:"object"`
-		CreatedAt    int64    `json:"created_at"`
-		Name         string   `json:"name"`
-		Description  string   `json:"description"`
-		Model        Model    `json:"model"`
-		Instructions string   `json:"instructions"`
-		Tools        []Tool   `json:"tools"`
-		FileIDs      []string `json:"file_ids"`
-		Metadata     Meta     `json:"metadata,omitempty"`
+		ID             string          `json:"id"`
+		Object         string          `json:"object"`
+		CreatedAt      int64           `json:"created_at"`
+		Name           string          `json:"name"`
+		Description    string          `json:"description"`
+		Model          Model           `json:"model"`
+		Instructions   string          `json:"instructions"`
+		Tools          []Tool          `json:"tools"`
+		FileIDs        []string        `json:"file_ids"`
+		Metadata       Meta            `json:"metadata,omitempty"`
+		Temperature    *float64        `json:"temperature,omitempty"`
+		TopP           *float64        `json:"top_p,omitempty"`
+		ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+		ToolResources  *ToolResources  `json:"tool_resources,omitempty"`
+	}
+
+	// ResponseFormat is sent and received as the string "auto" when its Type
+	// is ResponseFormatAuto, and as an object otherwise.
+	ResponseFormat struct {
+		Type       string      `json:"type"`
+		JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+	}
+
+	JSONSchema struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Schema      any    `json:"schema,omitempty"`
+		Strict      *bool  `json:"strict,omitempty"`
 	}
 
 	Tool struct {
@@ -104,6 +276,8 @@ type (
 		Name        string         `json:"name"`
 		Description string         `json:"description"`
 		Parameters  map[string]any `json:"parameters"`
+		// Strict makes the model follow the parameters schema exactly.
+		Strict *bool `json:"strict,omitempty"`
 	}
 
 	// Vector Store
@@ -122,6 +296,195 @@ type (
 		Metadata     map[string]any `json:"metadata"`
 		CreatedAt    int64          `json:"created_at"`
 		LastActiveAt int64          `json:"last_active_at"`
+		FileCounts   FileCounts     `json:"file_counts"`
+	}
+
+	VectorStoreList struct {
+		Object  string        `json:"object"`
+		Data    []VectorStore `json:"data"`
+		FirstID string        `json:"first_id"`
+		LastID  string        `json:"last_id"`
+		HasMore bool          `json:"has_more"`
+	}
+
+	VectorStoreFile struct {
+		ID            string `json:"id"`
+		Object        string `json:"object"`
+		UsageBytes    int64  `json:"usage_bytes"`
+		CreatedAt     int64  `json:"created_at"`
+		VectorStoreID string `json:"vector_store_id"`
+		// Status is one of the VectorStoreFileStatus constants. The file can
+		// only be searched once it is completed.
+		Status    string                `json:"status"`
+		LastError *VectorStoreFileError `json:"last_error,omitempty"`
+	}
+
+	// VectorStoreFileBatch adds files to a vector store in one go. Its
+	// status takes the same values as the status of a VectorStoreFile.
+	VectorStoreFileBatch struct {
+		ID            string     `json:"id"`
+		Object        string     `json:"object"`
+		CreatedAt     int64      `json:"created_at"`
+		VectorStoreID string     `json:"vector_store_id"`
+		Status        string     `json:"status"`
+		FileCounts    FileCounts `json:"file_counts"`
+	}
+
+	FileCounts struct {
+		InProgress int `json:"in_progress"`
+		Completed  int `json:"completed"`
+		Failed     int `json:"failed"`
+		Cancelled  int `json:"cancelled"`
+		Total      int `json:"total"`
+	}
+
+	VectorStoreFileError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+
+	VectorStoreFileList struct {
+		Object  string            `json:"object"`
+		Data    []VectorStoreFile `json:"data"`
+		FirstID string            `json:"first_id"`
+		LastID  string            `json:"last_id"`
+		HasMore bool              `json:"has_more"`
+	}
+
+	// Chat Completions
+	// https://platform.openai.com/docs/api-reference/chat/create
+
+	ChatCompletionRequest struct {
+		Model       Model         `json:"model"`
+		Messages    []ChatMessage `json:"messages"`
+		Temperature *float64      `json:"temperature,omitempty"`
+		MaxTokens   int           `json:"max_tokens,omitempty"`
+		Tools       []Tool        `json:"tools,omitempty"`
+		// StreamOptions only applies to CreateChatCompletionStream.
+		StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+	}
+
+	StreamOptions struct {
+		// IncludeUsage asks for the token usage of the completion, sent in a
+		// final StreamEventUsage event.
+		IncludeUsage bool `json:"include_usage"`
+	}
+
+	ChatMessage struct {
+		Role       string     `json:"role"`
+		Content    string     `json:"content"`
+		Name       string     `json:"name,omitempty"`
+		ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+		ToolCallID string     `json:"tool_call_id,omitempty"`
+	}
+
+	ChatCompletionResponse struct {
+		ID      string                 `json:"id"`
+		Object  string                 `json:"object"`
+		Created int64                  `json:"created"`
+		Model   string                 `json:"model"`
+		Choices []ChatCompletionChoice `json:"choices"`
+		Usage   *Usage                 `json:"usage,omitempty"`
+	}
+
+	ChatCompletionChoice struct {
+		Index        int         `json:"index"`
+		Message      ChatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	}
+
+	ChatCompletionChunk struct {
+		ID      string                      `json:"id"`
+		Object  string                      `json:"object"`
+		Created int64                       `json:"created"`
+		Model   string                      `json:"model"`
+		Choices []ChatCompletionChunkChoice `json:"choices"`
+		Usage   *Usage                      `json:"usage,omitempty"`
+	}
+
+	ChatCompletionChunkChoice struct {
+		Index        int         `json:"index"`
+		Delta        ChatMessage `json:"delta"`
+		FinishReason string      `json:"finish_reason"`
+	}
+
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	}
+
+	// Embeddings
+	// https://platform.openai.com/docs/api-reference/embeddings/create
+
+	EmbeddingRequest struct {
+		Model          Model    `json:"model"`
+		Input          []string `json:"input"`
+		Dimensions     int      `json:"dimensions,omitempty"`
+		EncodingFormat string   `json:"encoding_format,omitempty"`
+	}
+
+	EmbeddingResponse struct {
+		Object string      `json:"object"`
+		Data   []Embedding `json:"data"`
+		Model  string      `json:"model"`
+		Usage  *Usage      `json:"usage,omitempty"`
+	}
+
+	Embedding struct {
+		Object string    `json:"object"`
+		Index  int       `json:"index"`
+		Vector []float32 `json:"embedding"`
+	}
+
+	// Moderations
+	// https://platform.openai.com/docs/api-reference/moderations/create
+
+	ModerationRequest struct {
+		Input []string `json:"input"`
+		Model string   `json:"model,omitempty"`
+	}
+
+	ModerationResponse struct {
+		ID      string             `json:"id"`
+		Model   string             `json:"model"`
+		Results []ModerationResult `json:"results"`
+	}
+
+	// ModerationResult is the verdict on one input, in the order of the inputs.
+	// Categories and CategoryScores are keyed by category name, such as
+	// "harassment" or "self-harm/intent".
+	ModerationResult struct {
+		Flagged        bool               `json:"flagged"`
+		Categories     map[string]bool    `json:"categories"`
+		CategoryScores map[string]float64 `json:"category_scores"`
+	}
+
+	// Images
+	// https://platform.openai.com/docs/api-reference/images/create
+
+	ImageRequest struct {
+		Prompt  string `json:"prompt"`
+		Model   Model  `json:"model,omitempty"`
+		Size    string `json:"size,omitempty"`
+		Quality string `json:"quality,omitempty"`
+		N       int    `json:"n,omitempty"`
+		// ResponseFormat is ImageResponseFormatURL, the default, or
+		// ImageResponseFormatB64JSON to receive the images themselves.
+		ResponseFormat string `json:"response_format,omitempty"`
+	}
+
+	ImageResponse struct {
+		Created int64       `json:"created"`
+		Data    []ImageData `json:"data"`
+	}
+
+	// ImageData holds a generated image as either a URL, valid for an hour, or
+	// its decoded bytes, depending on the response format requested.
+	ImageData struct {
+		URL           string `json:"url,omitempty"`
+		B64JSON       []byte `json:"b64_json,omitempty"`
+		RevisedPrompt string `json:"revised_prompt,omitempty"`
 	}
 
 	// WhisperAI
@@ -129,6 +492,96 @@ type (
 	TranscribeAudioInput struct {
 		Name string
 		Data io.Reader
+		// Model is the transcription model, whisper-1 when empty. Newer
+		// models such as gpt-4o-transcribe only support the text and json
+		// formats.
+		Model Model
+		// Prompt guides the transcription, such as with the names of the
+		// speakers or the spelling of uncommon words.
+		Prompt string
+		// ResponseFormat is the format TranscribeAudio returns the
+		// transcription in, TranscriptionFormatText by default. Use
+		// TranscribeAudioVerbose for the typed verbose_json format.
+		ResponseFormat string
+		// Language is the ISO-639-1 code of the language of the audio, which
+		// improves accuracy and latency when known.
+		Language string
+		// Temperature ranges from 0 to 1; when unset the model picks it.
+		Temperature *float64
+	}
+
+	// SpeechRequest describes the audio to synthesize from a text.
+	// https://platform.openai.com/docs/api-reference/audio/createSpeech
+	SpeechRequest struct {
+		Model Model  `json:"model"`
+		Input string `json:"input"`
+		Voice string `json:"voice"`
+		// ResponseFormat is the audio format, such as "mp3", the default, or
+		// "opus", "aac", "flac", "wav" and "pcm".
+		ResponseFormat string `json:"response_format,omitempty"`
+		// Speed ranges from 0.25 to 4, with 1 as the default.
+		Speed float64 `json:"speed,omitempty"`
+	}
+
+	// Transcription is the verbose transcription of an audio file.
+	Transcription struct {
+		Task     string                 `json:"task"`
+		Language string                 `json:"language"`
+		Duration float64                `json:"duration"`
+		Text     string                 `json:"text"`
+		Segments []TranscriptionSegment `json:"segments"`
+	}
+
+	// TranscriptionSegment is a part of a transcription, timed in seconds from
+	// the start of the audio.
+	TranscriptionSegment struct {
+		ID               int     `json:"id"`
+		Seek             int     `json:"seek"`
+		Start            float64 `json:"start"`
+		End              float64 `json:"end"`
+		Text             string  `json:"text"`
+		Tokens           []int   `json:"tokens"`
+		Temperature      float64 `json:"temperature"`
+		AvgLogprob       float64 `json:"avg_logprob"`
+		CompressionRatio float64 `json:"compression_ratio"`
+		NoSpeechProb     float64 `json:"no_speech_prob"`
+	}
+
+	// Run
+	// https://platform.openai.com/docs/api-reference/runs/createRun
+
+	RunOptions struct {
+		TruncationStrategy *TruncationStrategy `json:"truncation_strategy,omitempty"`
+		ToolResources      *ToolResources      `json:"tool_resources,omitempty"`
+		Temperature        *float64            `json:"temperature,omitempty"`
+		// Instructions replaces the assistant's instructions for this run,
+		// discarding them.
+		Instructions string `json:"instructions,omitempty"`
+		// AdditionalInstructions is appended to the instructions of the run,
+		// the assistant's own or Instructions, to add per-request context such
+		// as the name of the user without discarding them.
+		AdditionalInstructions string `json:"additional_instructions,omitempty"`
+		// Metadata is attached to the run, such as a trace ID to correlate it
+		// with the request that started it.
+		Metadata Meta `json:"metadata,omitempty"`
+		// WaitForIdle makes RunThreadWithOptions wait for the active run of the
+		// thread, if any, to end before starting the new one, rather than have
+		// the API reject it. A new thread has no active run to wait for.
+		WaitForIdle bool `json:"-"`
+	}
+
+	// CreateThreadAndRunInput describes a thread created with its initial
+	// messages and run by the assistant in a single request.
+	CreateThreadAndRunInput struct {
+		AssistantID string
+		Messages    []ThreadMessage
+		Metadata    Meta
+		Options     *RunOptions
+	}
+
+	TruncationStrategy struct {
+		Type         string `json:"type"`
+		LastMessages int    `json:"last_messages,omitempty"`
 	}
 
 	// Yet to organize the below types
@@ -141,6 +594,35 @@ type (
 	ThreadMessage struct {
 		Role    string `json:"role"`
 		Content string `json:"content"`
+		// ContentParts is sent instead of Content when set, to mix text and
+		// images in a single message.
+		ContentParts []MessageContentPart `json:"-"`
+		// Attachments makes files available to the given tools for this
+		// message only.
+		Attachments []MessageAttachment `json:"attachments,omitempty"`
+	}
+
+	MessageAttachment struct {
+		FileID string `json:"file_id"`
+		Tools  []Tool `json:"tools"`
+	}
+
+	MessageContentPart struct {
+		Type      string     `json:"type"`
+		Text      string     `json:"text,omitempty"`
+		ImageURL  *ImageURL  `json:"image_url,omitempty"`
+		ImageFile *ImageFile `json:"image_file,omitempty"`
+	}
+
+	ImageURL struct {
+		URL    string `json:"url"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	// ImageFile references an image uploaded with the "vision" purpose.
+	ImageFile struct {
+		FileID string `json:"file_id"`
+		Detail string `json:"detail,omitempty"`
 	}
 
 	RunSteps struct {
@@ -155,6 +637,7 @@ type (
 		RunID       string      `json:"run_id"`
 		Status      string      `json:"status"`
 		StepDetails *StepDetail `json:"step_details"`
+		Usage       *Usage      `json:"usage,omitempty"`
 	}
 
 	StepDetail struct {
@@ -163,45 +646,98 @@ type (
 	}
 
 	ToolCall struct {
-		ID        string       `json:"id"`
-		Type      string       `json:"type"`
-		Function  FunctionCall `json:"function"`
-		Arguments string       `json:"arguments"`
+		ID         string          `json:"id"`
+		Type       string          `json:"type"`
+		Function   FunctionCall    `json:"function"`
+		FileSearch *FileSearchCall `json:"file_search,omitempty"`
+		// CodeInterpreter is set on code_interpreter tool calls of run steps.
+		CodeInterpreter *CodeInterpreterCall `json:"code_interpreter,omitempty"`
+	}
+
+	// CodeInterpreterCall holds the code run by a code_interpreter tool call
+	// and what it produced.
+	CodeInterpreterCall struct {
+		Input   string                  `json:"input"`
+		Outputs []CodeInterpreterOutput `json:"outputs,omitempty"`
+	}
+
+	// CodeInterpreterOutput is either the logs of the code, with type "logs",
+	// or an image it generated, with type "image".
+	CodeInterpreterOutput struct {
+		Type  string                `json:"type"`
+		Logs  string                `json:"logs,omitempty"`
+		Image *CodeInterpreterImage `json:"image,omitempty"`
+	}
+
+	CodeInterpreterImage struct {
+		FileID string `json:"file_id"`
+	}
+
+	// FileSearchCall holds the results of a file_search tool call. Their
+	// content is only returned by GetRunStepsWithContent.
+	FileSearchCall struct {
+		Results []FileSearchResult `json:"results,omitempty"`
 	}
 
+	FileSearchResult struct {
+		FileID   string                    `json:"file_id"`
+		FileName string                    `json:"file_name"`
+		Score    float64                   `json:"score"`
+		Content  []FileSearchResultContent `json:"content,omitempty"`
+	}
+
+	FileSearchResultContent struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+
+	// FunctionCall is the function a tool call invokes. Arguments holds the
+	// JSON-encoded arguments, as generated by the model.
 	FunctionCall struct {
 		Name      string `json:"name"`
 		Arguments string `json:"arguments"`
 	}
 
+	// ListResponse is a page of the uploaded files.
 	ListResponse struct {
-		Object  string `json:"object"`
-		Data    []any  `json:"data"`
-		FirstID string `json:"first_id"`
-		LastID  string `json:"last_id"`
-		HasMore bool   `json:"has_more"`
+		Object  string        `json:"object"`
+		Data    []FileDetails `json:"data"`
+		FirstID string        `json:"first_id"`
+		LastID  string        `json:"last_id"`
+		HasMore bool          `json:"has_more"`
 	}
 
 	FileUploadResponse struct {
 		ID        string `json:"id"`
 		Object    string `json:"object"`
+		Bytes     int64  `json:"bytes"`
+		Filename  string `json:"filename"`
 		Purpose   string `json:"purpose"`
 		CreatedAt int64  `json:"created_at"`
 	}
 
+	// FileContent is the downloaded content of a file.
+	FileContent struct {
+		Data        []byte
+		ContentType string
+		Filename    string
+	}
+
 	FileDetails struct {
 		ID        string `json:"id"`
 		Object    string `json:"object"`
+		Bytes     int64  `json:"bytes"`
 		Purpose   string `json:"purpose"`
 		Filename  string `json:"filename"`
 		CreatedAt int64  `json:"created_at"`
 	}
 
 	Thread struct {
-		ID        string `json:"id"`
-		Object    string `json:"object"`
-		CreatedAt int    `json:"created_at"`
-		Metadata  Meta   `json:"metadata"`
+		ID            string         `json:"id"`
+		Object        string         `json:"object"`
+		CreatedAt     int            `json:"created_at"`
+		Metadata      Meta           `json:"metadata"`
+		ToolResources *ToolResources `json:"tool_resources,omitempty"`
 	}
 
 	ThreadMessageList struct {
@@ -209,16 +745,18 @@ type (
 		Data    []MessageContent `json:"data"`
 		FirstID string           `json:"first_id"`
 		LastID  string           `json:"last_id"`
+		HasMore bool             `json:"has_more"`
 	}
 
 	MessageContent struct {
-		ID        string    `json:"id"`
-		Object    string    `json:"object"`
-		CreatedAt int64     `json:"created_at"`
-		ThreadID  string    `json:"thread_id"`
-		RunID     string    `json:"run_id"`
-		Role      string    `json:"role"`
-		Content   []Content `json:"content"`
+		ID          string              `json:"id"`
+		Object      string              `json:"object"`
+		CreatedAt   int64               `json:"created_at"`
+		ThreadID    string              `json:"thread_id"`
+		RunID       string              `json:"run_id"`
+		Role        string              `json:"role"`
+		Content     []Content           `json:"content"`
+		Attachments []MessageAttachment `json:"attachments,omitempty"`
 	}
 
 	Content struct {
@@ -233,12 +771,58 @@ type (
 	}
 
 	Annotation struct {
-		Type         string `json:"type"`
-		Text         string `json:"text"`
-		FileCitation *struct {
-			FileID string `json:"file_id"`
-			Quote  string `json:"quote"`
-		} `json:"file_citation,omitempty"`
+		Type         string        `json:"type"`
+		Text         string        `json:"text"`
+		StartIndex   int           `json:"start_index"`
+		EndIndex     int           `json:"end_index"`
+		FileCitation *FileCitation `json:"file_citation,omitempty"`
+	}
+
+	FileCitation struct {
+		FileID string `json:"file_id"`
+		Quote  string `json:"quote"`
+	}
+
+	// ResolvedCitation ties a citation marker in a message to the text of the
+	// file it quotes.
+	ResolvedCitation struct {
+		// Text is the marker in the message, such as "【4:0†source】".
+		Text       string `json:"text"`
+		StartIndex int    `json:"start_index"`
+		EndIndex   int    `json:"end_index"`
+		FileID     string `json:"file_id"`
+		FileName   string `json:"file_name,omitempty"`
+		Quote      string `json:"quote,omitempty"`
+	}
+
+	// ThreadExport is a whole conversation, as assembled by ExportThread.
+	ThreadExport struct {
+		Thread Thread `json:"thread"`
+		// Messages are in chronological order.
+		Messages []ExportedMessage `json:"messages"`
+		// Runs are in chronological order.
+		Runs []Run `json:"runs"`
+		// Usage totals the usage of the runs.
+		Usage Usage `json:"usage"`
+	}
+
+	// ExportedMessage is a message of a ThreadExport with its citations
+	// resolved.
+	ExportedMessage struct {
+		MessageContent
+		Citations []ResolvedCitation `json:"citations,omitempty"`
+	}
+
+	// CodeOutput is the code run by a code_interpreter tool call with its
+	// outputs, as returned by ExtractCodeOutputs.
+	CodeOutput struct {
+		StepID     string
+		ToolCallID string
+		Input      string
+		Logs       []string
+		// ImageFileIDs are the files of the generated images, to be downloaded
+		// with GetFileContent.
+		ImageFileIDs []string
 	}
 
 	Citation struct {
@@ -252,23 +836,27 @@ type (
 	}
 
 	Run struct {
-		ID             string          `json:"id"`
-		Object         string          `json:"object"`
-		CreatedAt      int64           `json:"created_at"`
-		ThreadID       string          `json:"thread_id"`
-		AssistantID    string          `json:"assistant_id"`
-		Status         string          `json:"status"`
-		StartedAt      int64           `json:"started_at,omitempty"`
-		ExpiresAt      int64           `json:"expires_at,omitempty"`
-		CancelledAt    int64           `json:"cancelled_at,omitempty"`
-		FailedAt       int64           `json:"failed_at,omitempty"`
-		CompletedAt    int64           `json:"completed_at,omitempty"`
-		LastError      *RunError       `json:"last_error,omitempty"`
-		Model          string          `json:"model"`
-		Instructions   string          `json:"instructions,omitempty"`
-		Tools          []Tool          `json:"tools"`
-		FileIDs        []string        `json:"file_ids"`
-		RequiredAction *RequiredAction `json:"required_action,omitempty"`
+		ID                string             `json:"id"`
+		Object            string             `json:"object"`
+		CreatedAt         int64              `json:"created_at"`
+		ThreadID          string             `json:"thread_id"`
+		AssistantID       string             `json:"assistant_id"`
+		Status            string             `json:"status"`
+		StartedAt         int64              `json:"started_at,omitempty"`
+		ExpiresAt         int64              `json:"expires_at,omitempty"`
+		CancelledAt       int64              `json:"cancelled_at,omitempty"`
+		FailedAt          int64              `json:"failed_at,omitempty"`
+		CompletedAt       int64              `json:"completed_at,omitempty"`
+		LastError         *RunError          `json:"last_error,omitempty"`
+		IncompleteDetails *IncompleteDetails `json:"incomplete_details,omitempty"`
+		Model             string             `json:"model"`
+		Instructions      string             `json:"instructions,omitempty"`
+		Tools             []Tool             `json:"tools"`
+		FileIDs           []string           `json:"file_ids"`
+		RequiredAction    *RequiredAction    `json:"required_action,omitempty"`
+		Metadata          Meta               `json:"metadata,omitempty"`
+		// Usage is only reported once the run has reached a terminal status.
+		Usage *Usage `json:"usage,omitempty"`
 	}
 
 	RunError struct {
@@ -276,9 +864,23 @@ type (
 		Message string `json:"message"`
 	}
 
+	RunList struct {
+		Object  string `json:"object"`
+		Data    []Run  `json:"data"`
+		FirstID string `json:"first_id"`
+		LastID  string `json:"last_id"`
+		HasMore bool   `json:"has_more"`
+	}
+
+	IncompleteDetails struct {
+		Reason string `json:"reason"`
+	}
+
+	// RequiredAction flattens the API's
+	// required_action.submit_tool_outputs.tool_calls into ToolCalls.
 	RequiredAction struct {
 		Type      string     `json:"type"`
-		ToolCalls []ToolCall `json:"tool_calls"`
+		ToolCalls []ToolCall `json:"-"`
 	}
 
 	StreamEvent struct {
@@ -291,14 +893,15 @@ type (
 		Snapshot string `json:"snapshot"`
 	}
 
+	// ToolCallDelta is a fragment of a streamed tool call. Only the first
+	// fragment of a call carries its ID, type and function name; the function
+	// arguments and code interpreter input are split across the fragments of
+	// the same Index. Use a ToolCallAccumulator to assemble them.
 	ToolCallDelta struct {
-		Type            string `json:"type"`
-		CodeInterpreter struct {
-			Input   string `json:"input,omitempty"`
-			Outputs []struct {
-				Type string `json:"type"`
-				Logs string `json:"logs,omitempty"`
-			} `json:"outputs,omitempty"`
-		} `json:"code_interpreter,omitempty"`
+		Index           int                  `json:"index"`
+		ID              string               `json:"id,omitempty"`
+		Type            string               `json:"type,omitempty"`
+		Function        *FunctionCall        `json:"function,omitempty"`
+		CodeInterpreter *CodeInterpreterCall `json:"code_interpreter,omitempty"`
 	}
 )
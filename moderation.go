@@ -0,0 +1,33 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CreateModeration classifies the inputs as potentially harmful or not, to
+// screen user content before it reaches an assistant. An empty model uses the
+// API's default moderation model.
+func (c *Client) CreateModeration(ctx context.Context, input []string, model string) (_ *ModerationResponse, err error) {
+	ctx, op := c.startOperation(ctx, "CreateModeration")
+	defer op.end(&err)
+
+	if len(input) == 0 {
+		return nil, fmt.Errorf("at least one input is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/moderations", ModerationRequest{
+		Input: input,
+		Model: model,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var moderation ModerationResponse
+	if err := c.sendRequest(req, &moderation); err != nil {
+		return nil, err
+	}
+	return &moderation, nil
+}
@@ -0,0 +1,112 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateModeration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        []string
+		model        string
+		wantBody     string
+		serverBody   string
+		serverStatus int
+		expected     *ModerationResponse
+		expectError  bool
+	}{
+		{
+			name:     "flagged input",
+			input:    []string{"hello", "I will hurt you"},
+			model:    "omni-moderation-latest",
+			wantBody: `{"input":["hello","I will hurt you"],"model":"omni-moderation-latest"}`,
+			serverBody: `{"id":"modr-123","model":"omni-moderation-latest","results":[` +
+				`{"flagged":false,"categories":{"violence":false},"category_scores":{"violence":0.001}},` +
+				`{"flagged":true,"categories":{"violence":true},"category_scores":{"violence":0.97}}]}`,
+			serverStatus: http.StatusOK,
+			expected: &ModerationResponse{
+				ID:    "modr-123",
+				Model: "omni-moderation-latest",
+				Results: []ModerationResult{
+					{
+						Categories:     map[string]bool{"violence": false},
+						CategoryScores: map[string]float64{"violence": 0.001},
+					},
+					{
+						Flagged:        true,
+						Categories:     map[string]bool{"violence": true},
+						CategoryScores: map[string]float64{"violence": 0.97},
+					},
+				},
+			},
+		},
+		{
+			name:         "default model",
+			input:        []string{"hello"},
+			wantBody:     `{"input":["hello"]}`,
+			serverBody:   `{"id":"modr-456","model":"omni-moderation-latest","results":[{"flagged":false}]}`,
+			serverStatus: http.StatusOK,
+			expected: &ModerationResponse{
+				ID:      "modr-456",
+				Model:   "omni-moderation-latest",
+				Results: []ModerationResult{{}},
+			},
+		},
+		{
+			name:        "no input",
+			expectError: true,
+		},
+		{
+			name:         "invalid model",
+			input:        []string{"hello"},
+			model:        "gpt-4o",
+			wantBody:     `{"input":["hello"],"model":"gpt-4o"}`,
+			serverBody:   `{"error":{"message":"Invalid model"}}`,
+			serverStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/moderations", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Empty(t, r.Header.Get("OpenAI-Beta"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.wantBody, string(body))
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverBody))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.CreateModeration(context.Background(), tt.input, tt.model)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package openai
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"slices"
+)
+
+// fileField is the file part of a multipart request body.
+type fileField struct {
+	name     string // form field name
+	filename string
+	data     io.Reader
+}
+
+// buildMultipart encodes the file and the form fields as a multipart body,
+// returning the body together with its content type. Fields are written in
+// key order so the body is deterministic.
+func buildMultipart(fields map[string]string, file fileField) (*bytes.Buffer, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writeMultipart(writer, fields, file); err != nil {
+		return nil, "", err
+	}
+	return &body, writer.FormDataContentType(), nil
+}
+
+// streamMultipart encodes the body like buildMultipart, but as it is read
+// rather than up front, so that the file is never held in memory. The body can
+// be read only once, and must be closed to release its writer. An error
+// reading the file is returned by the read of the body.
+func streamMultipart(fields map[string]string, file fileField) (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, fields, file))
+	}()
+	return pr, writer.FormDataContentType()
+}
+
+func writeMultipart(writer *multipart.Writer, fields map[string]string, file fileField) error {
+	part, err := writer.CreateFormFile(file.name, file.filename)
+	if err != nil {
+		return fmt.Errorf("could not create form file: %w", err)
+	}
+
+	if _, err := io.Copy(part, file.data); err != nil {
+		return fmt.Errorf("could not copy data to form file: %w", err)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if err := writer.WriteField(k, fields[k]); err != nil {
+			return fmt.Errorf("could not write %s field: %w", k, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("could not close multipart writer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package openai
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMultipart(t *testing.T) {
+	t.Parallel()
+
+	body, contentType, err := buildMultipart(
+		map[string]string{"purpose": "assistants", "model": "whisper-1"},
+		fileField{name: "file", filename: "notes.txt", data: strings.NewReader("hello")},
+	)
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	require.Equal(t, "multipart/form-data", mediaType)
+
+	reader := multipart.NewReader(body, params["boundary"])
+
+	part, err := reader.NextPart()
+	require.NoError(t, err)
+	require.Equal(t, "file", part.FormName())
+	require.Equal(t, "notes.txt", part.FileName())
+	data, err := io.ReadAll(part)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(data))
+
+	var names []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, part.FormName())
+	}
+	require.Equal(t, []string{"model", "purpose"}, names)
+}
+
+func TestStreamMultipart(t *testing.T) {
+	t.Parallel()
+
+	body, contentType := streamMultipart(
+		map[string]string{"purpose": "fine-tune"},
+		fileField{name: "file", filename: "train.jsonl", data: strings.NewReader(`{"messages":[]}`)},
+	)
+	defer body.Close()
+
+	_, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+
+	form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1 << 20)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fine-tune"}, form.Value["purpose"])
+	require.Len(t, form.File["file"], 1)
+	require.Equal(t, "train.jsonl", form.File["file"][0].Filename)
+}
+
+func TestStreamMultipart_ReadError(t *testing.T) {
+	t.Parallel()
+
+	readErr := errors.New("disk failure")
+	body, _ := streamMultipart(nil, fileField{
+		name:     "file",
+		filename: "train.jsonl",
+		data:     io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(readErr)),
+	})
+	defer body.Close()
+
+	_, err := io.ReadAll(body)
+	require.ErrorIs(t, err, readErr)
+}
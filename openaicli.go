@@ -1,9 +1,11 @@
 package openai
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Client represents an OpenAI API client
@@ -12,6 +14,16 @@ type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	baseURL    string
+	headers    http.Header
+	// attemptTimeout bounds each attempt of a retried request, when set.
+	attemptTimeout time.Duration
+	// retrySleep waits between the attempts of a retried request, with
+	// sleepContext when nil. Tests replace it to retry without delay.
+	retrySleep func(ctx context.Context, d time.Duration) error
+	// assistants caches GetAssistant responses, when enabled.
+	assistants *assistantCache
+	// errorHandler is told about the errors returned by methods, when set.
+	errorHandler ErrorHandler
 }
 
 // ClientOption allows configuring the client
@@ -24,6 +36,141 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
+// WithHeader adds a header sent with every request, such as a gateway token, a
+// tracing ID or the attribution headers of OpenAI-compatible gateways. The
+// headers are added in one place when the request is sent, so they apply to
+// every endpoint, streams and uploads included. Headers set by the client
+// itself for a request, like Authorization, take precedence.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Set(key, value)
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithOrganization sets the OpenAI-Organization header, billing the requests
+// to the organization for accounts that belong to several of them.
+func WithOrganization(id string) ClientOption {
+	return WithHeader("OpenAI-Organization", id)
+}
+
+// WithProject sets the OpenAI-Project header, attributing the requests to the
+// project, as needed for project-scoped usage and costs.
+func WithProject(id string) ClientOption {
+	return WithHeader("OpenAI-Project", id)
+}
+
+// WithAppAttribution sets the HTTP-Referer and X-Title headers that OpenRouter
+// uses to attribute requests to an app. Empty values are not sent.
+func WithAppAttribution(siteURL, title string) ClientOption {
+	return func(c *Client) {
+		if siteURL != "" {
+			WithHeader("HTTP-Referer", siteURL)(c)
+		}
+		if title != "" {
+			WithHeader("X-Title", title)(c)
+		}
+	}
+}
+
+// WithTimeout sets the overall timeout of each request, which includes reading
+// the response body. A zero value means no overall timeout, which is what
+// streaming runs and large downloads usually need.
+func WithTimeout(t time.Duration) ClientOption {
+	return func(c *Client) {
+		httpClient := c.copyHTTPClient()
+		httpClient.Timeout = t
+		c.httpClient = httpClient
+	}
+}
+
+// copyHTTPClient returns a copy of the HTTP client for an option to modify,
+// leaving the caller's one untouched. A nil HTTP client is copied as a zero
+// one, which behaves like http.DefaultClient.
+func (c *Client) copyHTTPClient() *http.Client {
+	if c.httpClient == nil {
+		return &http.Client{}
+	}
+	httpClient := *c.httpClient
+	return &httpClient
+}
+
+// WithAttemptTimeout bounds each attempt of a request, including reading its
+// response body, so that a hung attempt is cancelled and retried with a fresh
+// budget instead of consuming the whole deadline of the request's context.
+// Bodies handed back to the caller, like the audio of CreateSpeech, are bounded
+// only up to their response headers. Streaming requests are not retried and
+// are not affected.
+func WithAttemptTimeout(t time.Duration) ClientOption {
+	return func(c *Client) {
+		c.attemptTimeout = t
+	}
+}
+
+// WithAssistantCache caches the assistants returned by GetAssistant in memory
+// for ttl, for services that fetch the same assistants over and over.
+// ModifyAssistant refreshes the cached copy; changes made elsewhere are only
+// seen once it expires. Cached assistants share their slices and maps, which
+// must not be modified.
+func WithAssistantCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.assistants = nil
+			return
+		}
+		c.assistants = newAssistantCache(ttl)
+	}
+}
+
+// WithResponseHeaderTimeout limits how long to wait for the response headers
+// once the request is written, without bounding how long the body takes to be
+// read. It only applies when the HTTP client uses an *http.Transport.
+func WithResponseHeaderTimeout(t time.Duration) ClientOption {
+	return func(c *Client) {
+		httpClient := c.copyHTTPClient()
+
+		var transport *http.Transport
+		switch rt := httpClient.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = rt.Clone()
+		default:
+			c.logger.Warn("Response header timeout ignored, transport is not an *http.Transport")
+			return
+		}
+
+		transport.ResponseHeaderTimeout = t
+		httpClient.Transport = transport
+		c.httpClient = httpClient
+	}
+}
+
+// assistantsResponseHeaderTimeout is how long WithAssistantsDefaults waits for
+// the response headers, which the API sends promptly even for streamed runs.
+const assistantsResponseHeaderTimeout = time.Minute
+
+// WithAssistantsDefaults tunes the timeouts for the assistants API, whose runs,
+// code interpreter ones in particular, can take minutes. Polled runs are made
+// of short requests that no transport timeout cuts, but the overall timeout of
+// the HTTP client would cut a run streamed for longer than it, so it is
+// removed; a server that doesn't answer is still given up on once the response
+// headers are a minute late. Bound whole operations with the deadline of their
+// context instead. Options passed after this one override it.
+func WithAssistantsDefaults() ClientOption {
+	return func(c *Client) {
+		WithTimeout(0)(c)
+		WithResponseHeaderTimeout(assistantsResponseHeaderTimeout)(c)
+	}
+}
+
 // New creates a new OpenAI client
 func New(logger *slog.Logger, apiKey string, httpClient *http.Client, opts ...ClientOption) *Client {
 	c := Client{
@@ -37,3 +184,15 @@ func New(logger *slog.Logger, apiKey string, httpClient *http.Client, opts ...Cl
 	}
 	return &c
 }
+
+// do sends the request with the request options carried by its context
+// applied and the client's default headers added.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	applyRequestOptions(req)
+	for key, values := range c.headers {
+		if _, ok := req.Header[key]; !ok {
+			req.Header[key] = append([]string(nil), values...)
+		}
+	}
+	return c.httpClient.Do(req)
+}
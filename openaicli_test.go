@@ -1,10 +1,14 @@
 package openai
 
 import (
+	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -86,3 +90,230 @@ func TestWithBaseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", httpClient, WithTimeout(0))
+
+	require.Equal(t, time.Duration(0), client.httpClient.Timeout)
+	require.Equal(t, time.Minute, httpClient.Timeout, "caller's client must not be modified")
+}
+
+func TestWithResponseHeaderTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		transport http.RoundTripper
+		wantSet   bool
+	}{
+		{
+			name:    "default transport",
+			wantSet: true,
+		},
+		{
+			name:      "custom http transport",
+			transport: &http.Transport{ResponseHeaderTimeout: time.Minute},
+			wantSet:   true,
+		},
+		{
+			name:      "non http transport",
+			transport: roundTripperFunc(http.DefaultTransport.RoundTrip),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{Transport: tt.transport}
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", httpClient, WithResponseHeaderTimeout(5*time.Second))
+
+			if !tt.wantSet {
+				require.Same(t, httpClient, client.httpClient)
+				return
+			}
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			require.True(t, ok)
+			require.Equal(t, 5*time.Second, transport.ResponseHeaderTimeout)
+			if tt.transport != nil {
+				require.NotSame(t, tt.transport, transport, "caller's transport must not be modified")
+			}
+		})
+	}
+}
+
+func TestClientOptions_NilHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		opt               ClientOption
+		wantTimeout       time.Duration
+		wantHeaderTimeout time.Duration
+	}{
+		{
+			name:        "timeout",
+			opt:         WithTimeout(time.Minute),
+			wantTimeout: time.Minute,
+		},
+		{
+			name:              "response header timeout",
+			opt:               WithResponseHeaderTimeout(5 * time.Second),
+			wantHeaderTimeout: 5 * time.Second,
+		},
+		{
+			name:              "assistants defaults",
+			opt:               WithAssistantsDefaults(),
+			wantHeaderTimeout: assistantsResponseHeaderTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", nil, tt.opt)
+
+			require.NotNil(t, client.httpClient)
+			require.Equal(t, tt.wantTimeout, client.httpClient.Timeout)
+			if tt.wantHeaderTimeout != 0 {
+				transport, ok := client.httpClient.Transport.(*http.Transport)
+				require.True(t, ok)
+				require.Equal(t, tt.wantHeaderTimeout, transport.ResponseHeaderTimeout)
+			}
+		})
+	}
+}
+
+func TestWithAssistantsDefaults(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", httpClient, WithAssistantsDefaults())
+
+	require.Zero(t, client.httpClient.Timeout)
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.Equal(t, time.Minute, transport.ResponseHeaderTimeout)
+	require.Equal(t, 15*time.Second, httpClient.Timeout, "caller's client must not be modified")
+}
+
+func TestWithHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        []ClientOption
+		wantHeaders map[string]string
+	}{
+		{
+			name: "arbitrary header",
+			opts: []ClientOption{WithHeader("X-Custom", "value")},
+			wantHeaders: map[string]string{
+				"X-Custom":      "value",
+				"Authorization": "Bearer test-key",
+			},
+		},
+		{
+			name: "user agent",
+			opts: []ClientOption{WithUserAgent("my-app/1.0")},
+			wantHeaders: map[string]string{
+				"User-Agent": "my-app/1.0",
+			},
+		},
+		{
+			name: "app attribution",
+			opts: []ClientOption{WithAppAttribution("https://example.com", "My App")},
+			wantHeaders: map[string]string{
+				"HTTP-Referer": "https://example.com",
+				"X-Title":      "My App",
+			},
+		},
+		{
+			name: "app attribution without title",
+			opts: []ClientOption{WithAppAttribution("https://example.com", "")},
+			wantHeaders: map[string]string{
+				"HTTP-Referer": "https://example.com",
+				"X-Title":      "",
+			},
+		},
+		{
+			name: "organization and project",
+			opts: []ClientOption{WithOrganization("org-123"), WithProject("proj_456")},
+			wantHeaders: map[string]string{
+				"OpenAI-Organization": "org-123",
+				"OpenAI-Project":      "proj_456",
+				"Authorization":       "Bearer test-key",
+			},
+		},
+		{
+			name: "request headers take precedence",
+			opts: []ClientOption{WithHeader("Authorization", "Bearer other-key")},
+			wantHeaders: map[string]string{
+				"Authorization": "Bearer test-key",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, want := range tt.wantHeaders {
+					require.Equal(t, want, r.Header.Get(key), key)
+				}
+				json.NewEncoder(w).Encode(Thread{ID: "thread_123"})
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			opts := append([]ClientOption{WithBaseURL(server.URL)}, tt.opts...)
+			client := New(logger, "test-key", server.Client(), opts...)
+
+			_, err := client.GetThread(context.Background(), "thread_123")
+			require.NoError(t, err)
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestClient_Services(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/threads", r.URL.Path)
+		json.NewEncoder(w).Encode(Thread{ID: "thread_123"})
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	require.NotNil(t, client.Assistants())
+	require.NotNil(t, client.Runs())
+	require.NotNil(t, client.Files())
+	require.NotNil(t, client.VectorStores())
+	require.NotNil(t, client.Audio())
+
+	thread, err := client.Threads().CreateThread(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "thread_123", thread.ID)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
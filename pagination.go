@@ -0,0 +1,40 @@
+package openai
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// query encodes the non-zero params as a query string, including the leading
+// '?', or returns an empty string when all params are zero.
+func (p ListParams) query() string {
+	return encodeQuery(p.values())
+}
+
+// values returns the non-zero params as query values, to which endpoints can
+// add their own filters.
+func (p ListParams) values() url.Values {
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Order != "" {
+		v.Set("order", p.Order)
+	}
+	if p.After != "" {
+		v.Set("after", p.After)
+	}
+	if p.Before != "" {
+		v.Set("before", p.Before)
+	}
+	return v
+}
+
+// encodeQuery encodes the values as a query string, including the leading '?',
+// or returns an empty string when there are none.
+func encodeQuery(v url.Values) string {
+	if len(v) == 0 {
+		return ""
+	}
+	return "?" + v.Encode()
+}
@@ -0,0 +1,40 @@
+package openai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListParams_query(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		params ListParams
+		want   string
+	}{
+		{
+			name: "zero params",
+			want: "",
+		},
+		{
+			name:   "limit only",
+			params: ListParams{Limit: 20},
+			want:   "?limit=20",
+		},
+		{
+			name:   "all params",
+			params: ListParams{Limit: 5, Order: OrderAsc, After: "asst_1", Before: "asst_9"},
+			want:   "?after=asst_1&before=asst_9&limit=5&order=asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.params.query())
+		})
+	}
+}
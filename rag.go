@@ -0,0 +1,73 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// defaultRAGWaitTimeout bounds how long CreateRAGAssistant waits for the vector
+// store to be indexed when the input doesn't say.
+const defaultRAGWaitTimeout = 5 * time.Minute
+
+// CreateRAGAssistant sets up an assistant answering from the given files: it
+// uploads in.Files, creates a vector store holding them and in.FileIDs, waits
+// for the store to be indexed and creates the assistant with the file_search
+// tool searching it. The resources created before a failing step are not
+// cleaned up; the error tells which step failed.
+func (c *Client) CreateRAGAssistant(ctx context.Context, in RAGAssistantInput) (_ *Assistant, err error) {
+	ctx, op := c.startOperation(ctx, "CreateRAGAssistant")
+	defer op.end(&err)
+
+	if len(in.Files) == 0 && len(in.FileIDs) == 0 {
+		return nil, fmt.Errorf("at least one file or file ID is required")
+	}
+
+	fileIDs := slices.Clone(in.FileIDs)
+	for _, file := range in.Files {
+		uploaded, err := c.UploadFileNamed(ctx, file.Data, FilePurposeAssistants, file.Name)
+		if err != nil {
+			return nil, fmt.Errorf("could not upload file '%s': %w", file.Name, err)
+		}
+		fileIDs = append(fileIDs, uploaded.ID)
+	}
+
+	storeName := in.VectorStoreName
+	if storeName == "" {
+		storeName = in.Assistant.Name
+	}
+	store, err := c.CreateVectorStore(ctx, &CreateVectorStoreInput{
+		Name:    storeName,
+		FileIDs: fileIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create vector store: %w", err)
+	}
+
+	timeout := in.WaitTimeout
+	if timeout <= 0 {
+		timeout = defaultRAGWaitTimeout
+	}
+	if err := c.WaitForVectorStoreCompletion(ctx, store.ID, timeout, 10*time.Second); err != nil {
+		return nil, fmt.Errorf("could not wait for vector store '%s': %w", store.ID, err)
+	}
+
+	assistantInput := in.Assistant
+	assistantInput.Tools = slices.Clone(assistantInput.Tools)
+	if !slices.ContainsFunc(assistantInput.Tools, func(t Tool) bool { return t.Type == ToolTypeFileSearch }) {
+		assistantInput.Tools = append(assistantInput.Tools, Tool{Type: ToolTypeFileSearch})
+	}
+
+	fileSearch := FileSearch{VectorStoreIDs: []string{store.ID}}
+	if existing := assistantInput.ToolResources.FileSearch; existing != nil {
+		fileSearch.VectorStoreIDs = append(slices.Clone(existing.VectorStoreIDs), store.ID)
+	}
+	assistantInput.ToolResources.FileSearch = &fileSearch
+
+	assistant, err := c.CreateAssistant(ctx, &assistantInput)
+	if err != nil {
+		return nil, fmt.Errorf("could not create assistant: %w", err)
+	}
+	return assistant, nil
+}
@@ -0,0 +1,143 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateRAGAssistant(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          RAGAssistantInput
+		storeStatus    string
+		wantStoreFiles []string
+		wantStoreName  string
+		wantTools      []Tool
+		wantStores     []string
+		expectError    bool
+	}{
+		{
+			name: "uploads files and existing IDs",
+			input: RAGAssistantInput{
+				Assistant: CreateAssistantInput{Name: "Support", Instructions: "Answer from the manuals."},
+				Files: []RAGFile{
+					{Name: "manual.pdf", Data: strings.NewReader("manual")},
+					{Name: "faq.md", Data: strings.NewReader("faq")},
+				},
+				FileIDs: []string{"file-existing"},
+			},
+			storeStatus:    "completed",
+			wantStoreFiles: []string{"file-existing", "file-manual.pdf", "file-faq.md"},
+			wantStoreName:  "Support",
+			wantTools:      []Tool{{Type: ToolTypeFileSearch}},
+			wantStores:     []string{"vs_123"},
+		},
+		{
+			name: "keeps configured tools and stores",
+			input: RAGAssistantInput{
+				Assistant: CreateAssistantInput{
+					Name:          "Analyst",
+					Tools:         []Tool{{Type: ToolTypeCodeInterpreter}, {Type: ToolTypeFileSearch}},
+					ToolResources: ToolResources{FileSearch: &FileSearch{VectorStoreIDs: []string{"vs_shared"}}},
+				},
+				FileIDs:         []string{"file-existing"},
+				VectorStoreName: "Reports",
+			},
+			storeStatus:    "completed",
+			wantStoreFiles: []string{"file-existing"},
+			wantStoreName:  "Reports",
+			wantTools:      []Tool{{Type: ToolTypeCodeInterpreter}, {Type: ToolTypeFileSearch}},
+			wantStores:     []string{"vs_shared", "vs_123"},
+		},
+		{
+			name: "indexing fails",
+			input: RAGAssistantInput{
+				Assistant: CreateAssistantInput{Name: "Support"},
+				FileIDs:   []string{"file-existing"},
+			},
+			storeStatus:    "failed",
+			wantStoreFiles: []string{"file-existing"},
+			wantStoreName:  "Support",
+			expectError:    true,
+		},
+		{
+			name:        "no files",
+			input:       RAGAssistantInput{Assistant: CreateAssistantInput{Name: "Support"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu                sync.Mutex
+				assistantsCreated int
+			)
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /files", func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseMultipartForm(32<<20))
+				require.Equal(t, FilePurposeAssistants, r.FormValue("purpose"))
+
+				_, header, err := r.FormFile("file")
+				require.NoError(t, err)
+				json.NewEncoder(w).Encode(FileUploadResponse{ID: "file-" + header.Filename, Filename: header.Filename})
+			})
+			mux.HandleFunc("GET /files/{fileID}", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(FileDetails{ID: r.PathValue("fileID"), Filename: "document.pdf"})
+			})
+			mux.HandleFunc("POST /vector_stores", func(w http.ResponseWriter, r *http.Request) {
+				var in CreateVectorStoreInput
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+				require.Equal(t, tt.wantStoreName, in.Name)
+				require.Equal(t, tt.wantStoreFiles, in.FileIDs)
+
+				json.NewEncoder(w).Encode(VectorStore{ID: "vs_123", Status: "in_progress"})
+			})
+			mux.HandleFunc("GET /vector_stores/vs_123", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(VectorStore{ID: "vs_123", Status: tt.storeStatus})
+			})
+			mux.HandleFunc("POST /assistants", func(w http.ResponseWriter, r *http.Request) {
+				var in CreateAssistantInput
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+				require.Equal(t, tt.wantTools, in.Tools)
+				require.NotNil(t, in.ToolResources.FileSearch)
+				require.Equal(t, tt.wantStores, in.ToolResources.FileSearch.VectorStoreIDs)
+
+				mu.Lock()
+				assistantsCreated++
+				mu.Unlock()
+				json.NewEncoder(w).Encode(Assistant{ID: "asst_123", Name: in.Name, Tools: in.Tools, ToolResources: &in.ToolResources})
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			assistant, err := client.CreateRAGAssistant(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				require.Zero(t, assistantsCreated)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "asst_123", assistant.ID)
+			require.Equal(t, tt.wantStores, assistant.ToolResources.FileSearch.VectorStoreIDs)
+			require.Equal(t, 1, assistantsCreated)
+		})
+	}
+}
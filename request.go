@@ -0,0 +1,85 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// betaPaths are the path prefixes of the endpoints that need the assistants
+// beta header.
+var betaPaths = []string{"/assistants", "/threads", "/vector_stores"}
+
+// newRequest builds a request to path, relative to the base URL, with the
+// authorization header set, and the assistants beta header for the endpoints
+// that need it. A non-nil body is encoded as JSON, unless it is an io.Reader,
+// which is sent as is for the caller to set its content type.
+func (c *Client) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	var (
+		reader io.Reader
+		isJSON bool
+	)
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		reader = b
+	default:
+		jsonData, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonData)
+		isJSON = true
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+
+	if isJSON {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if isBetaPath(path) {
+		req.Header.Set("OpenAI-Beta", "assistants=v2")
+	}
+	return req, nil
+}
+
+func isBetaPath(path string) bool {
+	for _, prefix := range betaPaths {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") || strings.HasPrefix(path, prefix+"?") {
+			return true
+		}
+	}
+	return false
+}
+
+// sendRequest sends the request with retries and decodes the JSON response
+// into out, when not nil. Responses with a status other than 2xx are returned
+// as an *APIError.
+func (c *Client) sendRequest(req *http.Request, out any) error {
+	resp, err := c.doWithRetry(req)
+	if err != nil {
+		return fmt.Errorf("could not send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return newAPIError(resp)
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+}
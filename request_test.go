@@ -0,0 +1,83 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_newRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		path            string
+		body            any
+		wantBody        string
+		wantContentType string
+		wantBeta        string
+	}{
+		{
+			name:     "beta endpoint without body",
+			path:     "/threads/thread_123/runs",
+			wantBeta: "assistants=v2",
+		},
+		{
+			name:            "JSON body",
+			path:            "/chat/completions",
+			body:            map[string]string{"model": "gpt-4o"},
+			wantBody:        `{"model":"gpt-4o"}`,
+			wantContentType: "application/json",
+		},
+		{
+			name:     "reader body is sent as is",
+			path:     "/files",
+			body:     strings.NewReader("raw"),
+			wantBody: "raw",
+		},
+		{
+			name:     "beta endpoint with query",
+			path:     "/vector_stores?limit=5",
+			wantBeta: "assistants=v2",
+		},
+		{
+			name: "prefix of a beta endpoint",
+			path: "/assistants_archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &Client{baseURL: "https://api.example.com/v1", apiKey: "test-key"}
+			req, err := client.newRequest(context.Background(), http.MethodPost, tt.path, tt.body)
+			require.NoError(t, err)
+
+			require.Equal(t, "https://api.example.com/v1"+tt.path, req.URL.String())
+			require.Equal(t, "Bearer test-key", req.Header.Get("Authorization"))
+			require.Equal(t, tt.wantContentType, req.Header.Get("Content-Type"))
+			require.Equal(t, tt.wantBeta, req.Header.Get("OpenAI-Beta"))
+
+			if tt.body == nil {
+				require.Nil(t, req.Body)
+				return
+			}
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
+
+func TestClient_newRequest_MarshalError(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{baseURL: "https://api.example.com/v1", apiKey: "test-key"}
+	_, err := client.newRequest(context.Background(), http.MethodPost, "/chat/completions", make(chan int))
+	require.ErrorContains(t, err, "could not marshal request")
+}
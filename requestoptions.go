@@ -0,0 +1,106 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// RequestOption customizes a single call, as opposed to a ClientOption that
+// applies to every request of the client. Attach them to the context of any
+// client method with WithRequestOptions, or pass them to Do.
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	headers http.Header
+	query   url.Values
+}
+
+// WithRequestHeader sets a header on the request, taking precedence over the
+// headers the client sets itself.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(cfg *requestConfig) {
+		if cfg.headers == nil {
+			cfg.headers = make(http.Header)
+		}
+		cfg.headers.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header so that a request
+// retried after an ambiguous failure is not applied twice.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithRequestHeader("Idempotency-Key", key)
+}
+
+// WithRequestUserAgent overrides the User-Agent of the request.
+func WithRequestUserAgent(userAgent string) RequestOption {
+	return WithRequestHeader("User-Agent", userAgent)
+}
+
+// WithQueryParam sets a query parameter on the request, replacing any value
+// the client method set for the same key.
+func WithQueryParam(key, value string) RequestOption {
+	return func(cfg *requestConfig) {
+		if cfg.query == nil {
+			cfg.query = make(url.Values)
+		}
+		cfg.query.Set(key, value)
+	}
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context carrying the options, to be applied to
+// every request sent with it. Options already carried by ctx are kept and
+// applied first. Use a per-call context.WithTimeout for a per-call timeout.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	existing, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	all := make([]RequestOption, 0, len(existing)+len(opts))
+	all = append(all, existing...)
+	all = append(all, opts...)
+	return context.WithValue(ctx, requestOptionsKey{}, all)
+}
+
+// applyRequestOptions applies the options carried by the request's context
+// to the request. It is safe to apply them again to the same request when it
+// is retried.
+func applyRequestOptions(req *http.Request) {
+	opts, _ := req.Context().Value(requestOptionsKey{}).([]RequestOption)
+	if len(opts) == 0 {
+		return
+	}
+
+	var cfg requestConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	for key, values := range cfg.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if len(cfg.query) > 0 {
+		query := req.URL.Query()
+		for key, values := range cfg.query {
+			query[key] = values
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// Do sends a request to an endpoint the client has no method for. The path is
+// relative to the base URL, in is encoded as the JSON body when not nil, and
+// the response is decoded into out when not nil. Requests are retried like
+// those of the other methods, and the OpenAI-Beta header is set for the
+// assistants, threads and vector stores endpoints; other endpoints in beta need
+// theirs passed with WithRequestHeader.
+func (c *Client) Do(ctx context.Context, method, path string, in, out any, opts ...RequestOption) (err error) {
+	ctx, op := c.startOperation(ctx, "Do")
+	defer op.end(&err)
+
+	req, err := c.newRequest(WithRequestOptions(ctx, opts...), method, path, in)
+	if err != nil {
+		return err
+	}
+	return c.sendRequest(req, out)
+}
@@ -0,0 +1,132 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestOptions(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		require.Equal(t, "key_123", r.Header.Get("Idempotency-Key"))
+		require.Equal(t, "custom-agent", r.Header.Get("User-Agent"))
+		require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		require.Equal(t, "limit=5&order=asc", r.URL.RawQuery)
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(RunList{Object: "list"})
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(),
+		WithBaseURL(server.URL),
+		WithUserAgent("default-agent"),
+	)
+	client.retrySleep = withoutRetryDelay(nil, nil)
+
+	ctx := WithRequestOptions(context.Background(), WithIdempotencyKey("key_123"))
+	ctx = WithRequestOptions(ctx, WithRequestUserAgent("custom-agent"), WithQueryParam("limit", "5"))
+
+	_, err := client.ListRuns(ctx, "thread_123", ListParams{Limit: 20, Order: OrderAsc})
+	require.NoError(t, err)
+	require.Equal(t, 2, attempts)
+}
+
+func TestClient_Do(t *testing.T) {
+	t.Parallel()
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		in           any
+		opts         []RequestOption
+		serverStatus int
+		serverBody   string
+		wantHeader   string
+		wantBody     string
+		want         *payload
+		wantErr      bool
+	}{
+		{
+			name:         "post with body and options",
+			method:       http.MethodPost,
+			in:           payload{Name: "in"},
+			opts:         []RequestOption{WithRequestHeader("OpenAI-Beta", "assistants=v2")},
+			serverStatus: http.StatusOK,
+			serverBody:   `{"name":"out"}`,
+			wantHeader:   "assistants=v2",
+			wantBody:     `{"name":"in"}`,
+			want:         &payload{Name: "out"},
+		},
+		{
+			name:         "get without body",
+			method:       http.MethodGet,
+			serverStatus: http.StatusOK,
+			serverBody:   `{"name":"out"}`,
+			want:         &payload{Name: "out"},
+		},
+		{
+			name:         "api error",
+			method:       http.MethodGet,
+			serverStatus: http.StatusNotFound,
+			serverBody:   `{"error":{"message":"not found"}}`,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tt.method, r.Method)
+				require.Equal(t, "/custom/endpoint", r.URL.Path)
+				require.Equal(t, tt.wantHeader, r.Header.Get("OpenAI-Beta"))
+
+				if tt.wantBody != "" {
+					require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+					var body json.RawMessage
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					require.JSONEq(t, tt.wantBody, string(body))
+				}
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverBody))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			var got payload
+			err := client.Do(context.Background(), tt.method, "/custom/endpoint", tt.in, &got, tt.opts...)
+			if tt.wantErr {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, tt.serverStatus, apiErr.StatusCode)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, &got)
+		})
+	}
+}
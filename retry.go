@@ -0,0 +1,193 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	maxRetries     = 5
+	baseRetryDelay = 500 * time.Millisecond
+	maxRetryDelay  = 5 * time.Second
+	// maxRetryAfter caps the delay a Retry-After header may ask for, so that a
+	// bogus value cannot park a request indefinitely.
+	maxRetryAfter = time.Minute
+)
+
+// doWithRetry sends the request, retrying on network errors, rate limits and
+// server errors with exponential backoff, or after the delay requested by the
+// server's Retry-After header when present, up to maxRetryAfter. Other
+// responses, successful or not, are returned as is for the caller to check the
+// status code, as is the last response once the retries are exhausted. The
+// request body is replayed from req.GetBody on each retry; requests whose body
+// cannot be replayed are sent only once. The attempt timeout, when set, covers
+// reading the response body.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	return c.retry(req, true)
+}
+
+// doWithRetryHeaders is doWithRetry for responses whose body is handed back to
+// the caller, like generated speech: the attempt timeout stops once the
+// response headers arrive, so reading a long body is bounded only by the
+// request's context.
+func (c *Client) doWithRetryHeaders(req *http.Request) (*http.Response, error) {
+	return c.retry(req, false)
+}
+
+func (c *Client) retry(req *http.Request, boundBody bool) (*http.Response, error) {
+	ctx := req.Context()
+
+	sleep := c.retrySleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+
+	var (
+		attempts int
+		lastErr  error
+	)
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		attempts++
+		if attempt > 0 {
+			if err := rewindBody(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.sendAttempt(req, boundBody)
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		if ctx.Err() != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, fmt.Errorf("request cancelled or timed out: %w", ctx.Err())
+		}
+
+		lastAttempt := attempt == maxRetries-1 || (req.Body != nil && req.GetBody == nil)
+		if err == nil && lastAttempt {
+			return resp, nil
+		}
+
+		delay := time.Duration(float64(baseRetryDelay) * math.Pow(2, float64(attempt)))
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
+			}
+
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			lastErr = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+
+		if lastAttempt {
+			break
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("request cancelled or timed out: %w", err)
+		}
+	}
+	if attempts == 1 {
+		return nil, fmt.Errorf("failed after 1 attempt: %w", lastErr)
+	}
+	return nil, fmt.Errorf("failed after %d attempts: %w", attempts, lastErr)
+}
+
+// sendAttempt sends a single attempt of the request, bounded by the client's
+// attempt timeout when set, up to the end of the response body when boundBody
+// is true and up to the response headers otherwise. The attempt's context is
+// released when the response body is closed.
+func (c *Client) sendAttempt(req *http.Request, boundBody bool) (*http.Response, error) {
+	if c.attemptTimeout <= 0 {
+		return c.do(req)
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := time.AfterFunc(c.attemptTimeout, cancel)
+	release := func() {
+		timer.Stop()
+		cancel()
+	}
+
+	resp, err := c.do(req.WithContext(ctx))
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if !boundBody {
+		timer.Stop()
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: release}
+	return resp, nil
+}
+
+// cancelOnClose cancels a context once the body it wraps is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// rewindBody resets the request body so the request can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Errorf("could not rewind request body: %w", err)
+	}
+	req.Body = body
+	return nil
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses a Retry-After header holding either a number of
+// seconds or an HTTP date, capping the delay at maxRetryAfter.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		// Compare in seconds, as a huge value would overflow a Duration
+		if seconds > int(maxRetryAfter/time.Second) {
+			return maxRetryAfter, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return min(max(date.Sub(now), 0), maxRetryAfter), true
+}
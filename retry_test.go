@@ -0,0 +1,296 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withoutRetryDelay returns a retrySleep that retries without delay,
+// recording the delays it would have waited in delays when not nil.
+func withoutRetryDelay(mu *sync.Mutex, delays *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, d time.Duration) error {
+		if delays != nil {
+			mu.Lock()
+			*delays = append(*delays, d)
+			mu.Unlock()
+		}
+		return ctx.Err()
+	}
+}
+
+func TestClient_doWithRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		responses    []int
+		wantStatus   int
+		wantCalls    int
+		expectDelays []time.Duration
+	}{
+		{
+			name:       "success on first attempt",
+			responses:  []int{http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:         "retries server errors",
+			responses:    []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantCalls:    3,
+			expectDelays: []time.Duration{500 * time.Millisecond, time.Second},
+		},
+		{
+			name:         "retries rate limits",
+			responses:    []int{http.StatusTooManyRequests, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantCalls:    2,
+			expectDelays: []time.Duration{500 * time.Millisecond},
+		},
+		{
+			name:         "returns the last response once the retries are exhausted",
+			responses:    []int{http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantCalls:    5,
+			expectDelays: []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:       "client errors are not retried",
+			responses:  []int{http.StatusBadRequest},
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, `{"hello":"world"}`, string(body), "body must be replayed on every attempt")
+
+				w.WriteHeader(tt.responses[min(calls, len(tt.responses)-1)])
+				calls++
+			}))
+			defer server.Close()
+
+			var (
+				mu     sync.Mutex
+				delays []time.Duration
+			)
+			client := &Client{httpClient: server.Client(), retrySleep: withoutRetryDelay(&mu, &delays)}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, bytes.NewBufferString(`{"hello":"world"}`))
+			require.NoError(t, err)
+
+			resp, err := client.doWithRetry(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+			require.Equal(t, tt.wantCalls, calls)
+			require.Equal(t, tt.expectDelays, delays)
+		})
+	}
+}
+
+func TestClient_doWithRetry_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// Longer than the default backoff, which starts at 500ms
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		delays []time.Duration
+	)
+	client := &Client{httpClient: server.Client(), retrySleep: withoutRetryDelay(&mu, &delays)}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.doWithRetry(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 2, calls)
+	require.Equal(t, []time.Duration{2 * time.Second}, delays)
+}
+
+func TestClient_doWithRetry_NetworkError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		body      func() io.Reader
+		wantCalls int
+		wantErr   string
+	}{
+		{
+			name:      "replayable body",
+			body:      func() io.Reader { return bytes.NewBufferString("{}") },
+			wantCalls: 5,
+			wantErr:   "failed after 5 attempts",
+		},
+		{
+			name: "body that cannot be replayed",
+			body: func() io.Reader {
+				// Hide the concrete type so that no GetBody is set
+				return io.MultiReader(strings.NewReader("{}"))
+			},
+			wantCalls: 1,
+			wantErr:   "failed after 1 attempt:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				calls.Add(1)
+				return nil, io.ErrUnexpectedEOF
+			})}
+			client := &Client{httpClient: httpClient, retrySleep: withoutRetryDelay(nil, nil)}
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.example.com/v1/threads", tt.body())
+			require.NoError(t, err)
+
+			_, err = client.doWithRetry(req)
+			require.ErrorContains(t, err, tt.wantErr)
+			require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+			require.EqualValues(t, tt.wantCalls, calls.Load())
+		})
+	}
+}
+
+func TestClient_doWithRetry_AttemptTimeout(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Read the body so the server notices when the client goes away
+		_, _ = io.ReadAll(r.Body)
+		if calls.Add(1) == 1 {
+			// Hang the first attempt until the client gives up on it
+			<-r.Context().Done()
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), attemptTimeout: 50 * time.Millisecond, retrySleep: withoutRetryDelay(nil, nil)}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, bytes.NewBufferString("{}"))
+	require.NoError(t, err)
+
+	resp, err := client.doWithRetry(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// The attempt's context must stay alive until the body has been read
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "ok", string(body))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 13, 16, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "seconds",
+			value:  "7",
+			want:   7 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "http date",
+			value:  "Mon, 13 Jan 2025 16:00:30 GMT",
+			want:   30 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "http date in the past",
+			value:  "Mon, 13 Jan 2025 15:59:00 GMT",
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "oversized seconds",
+			value:  "86400",
+			want:   maxRetryAfter,
+			wantOK: true,
+		},
+		{
+			name:   "seconds overflowing a duration",
+			value:  "9223372036854775807",
+			want:   maxRetryAfter,
+			wantOK: true,
+		},
+		{
+			name:   "http date far in the future",
+			value:  "Tue, 13 Jan 2026 16:00:00 GMT",
+			want:   maxRetryAfter,
+			wantOK: true,
+		},
+		{
+			name:  "missing",
+			value: "",
+		},
+		{
+			name:  "negative seconds",
+			value: "-3",
+		},
+		{
+			name:  "garbage",
+			value: "soon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tt.value, now)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,36 +2,64 @@ package openai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-
-	"github.com/wiselead-ai/httpclient"
+	"net/url"
 )
 
-func (c *Client) GetRunSteps(ctx context.Context, threadID, runID string) (*RunSteps, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/threads/%s/runs/%s/steps", c.baseURL, threadID, runID),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
-	}
+// fileSearchContentInclude asks the run steps endpoint for the content of the
+// file_search results, which it leaves out by default.
+const fileSearchContentInclude = "step_details.tool_calls[*].file_search.results[*].content"
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+func (c *Client) GetRunSteps(ctx context.Context, threadID, runID string) (_ *RunSteps, err error) {
+	ctx, op := c.startOperation(ctx, "GetRunSteps")
+	defer op.end(&err)
+
+	return c.getRunSteps(ctx, threadID, runID, "")
+}
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
+// GetRunStepsWithContent retrieves the run steps like GetRunSteps, including
+// the content of the chunks file_search retrieved, which ResolveCitations uses
+// to quote the cited files.
+func (c *Client) GetRunStepsWithContent(ctx context.Context, threadID, runID string) (_ *RunSteps, err error) {
+	ctx, op := c.startOperation(ctx, "GetRunStepsWithContent")
+	defer op.end(&err)
+
+	query := "?" + url.Values{"include[]": {fileSearchContentInclude}}.Encode()
+	return c.getRunSteps(ctx, threadID, runID, query)
+}
+
+func (c *Client) getRunSteps(ctx context.Context, threadID, runID, query string) (*RunSteps, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/runs/%s/steps%s", threadID, runID, query), nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	var steps RunSteps
-	if err := json.NewDecoder(resp.Body).Decode(&steps); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if err := c.sendRequest(req, &steps); err != nil {
+		return nil, err
 	}
 	return &steps, nil
 }
+
+// ListRuns retrieves a page of the runs of the thread, most recent first
+// unless params.Order says otherwise.
+func (c *Client) ListRuns(ctx context.Context, threadID string, params ListParams) (_ *RunList, err error) {
+	ctx, op := c.startOperation(ctx, "ListRuns")
+	defer op.end(&err)
+
+	if threadID == "" {
+		return nil, fmt.Errorf("thread ID is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/runs%s", threadID, params.query()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs RunList
+	if err := c.sendRequest(req, &runs); err != nil {
+		return nil, err
+	}
+	return &runs, nil
+}
@@ -88,6 +88,7 @@ func TestClient_GetRunSteps(t *testing.T) {
 				httpClient: server.Client(),
 				baseURL:    server.URL,
 				apiKey:     "test-key",
+				retrySleep: withoutRetryDelay(nil, nil),
 			}
 
 			result, err := client.GetRunSteps(context.Background(), tt.threadID, tt.runID)
@@ -101,3 +102,125 @@ func TestClient_GetRunSteps(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ListRuns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		threadID       string
+		params         ListParams
+		expectedQuery  string
+		serverResponse *RunList
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name:     "successful listing",
+			threadID: "thread_123",
+			serverResponse: &RunList{
+				Object: "list",
+				Data: []Run{
+					{ID: "run_2", ThreadID: "thread_123", Status: RunStatusInProgress},
+					{ID: "run_1", ThreadID: "thread_123", Status: RunStatusCompleted},
+				},
+				FirstID: "run_2",
+				LastID:  "run_1",
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "paginated",
+			threadID:      "thread_123",
+			params:        ListParams{Limit: 1, After: "run_2"},
+			expectedQuery: "after=run_2&limit=1",
+			serverResponse: &RunList{
+				Object:  "list",
+				Data:    []Run{{ID: "run_1", ThreadID: "thread_123", Status: RunStatusCompleted}},
+				FirstID: "run_1",
+				LastID:  "run_1",
+				HasMore: true,
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "not found",
+			threadID:      "thread_invalid",
+			serverStatus:  http.StatusNotFound,
+			expectedError: true,
+		},
+		{
+			name:          "empty thread ID",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/"+tt.threadID+"/runs", r.URL.Path)
+				require.Equal(t, tt.expectedQuery, r.URL.RawQuery)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient: server.Client(),
+				baseURL:    server.URL,
+				apiKey:     "test-key",
+			}
+
+			result, err := client.ListRuns(context.Background(), tt.threadID, tt.params)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_GetRunStepsWithContent(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/threads/thread_123/runs/run_456/steps", r.URL.Path)
+		require.Equal(t,
+			[]string{"step_details.tool_calls[*].file_search.results[*].content"},
+			r.URL.Query()["include[]"])
+
+		w.Write([]byte(`{"object":"list","data":[{"id":"step_1","step_details":{"type":"tool_calls","tool_calls":[` +
+			`{"id":"call_1","type":"file_search","file_search":{"results":[` +
+			`{"file_id":"file-1","file_name":"a.pdf","score":0.8,"content":[{"type":"text","text":"quoted"}]}]}}]}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: server.Client(),
+		baseURL:    server.URL,
+		apiKey:     "test-key",
+	}
+
+	steps, err := client.GetRunStepsWithContent(context.Background(), "thread_123", "run_456")
+	require.NoError(t, err)
+	require.Len(t, steps.Data, 1)
+	require.Equal(t, &FileSearchCall{
+		Results: []FileSearchResult{{
+			FileID:   "file-1",
+			FileName: "a.pdf",
+			Score:    0.8,
+			Content:  []FileSearchResultContent{{Type: ContentTypeText, Text: "quoted"}},
+		}},
+	}, steps.Data[0].StepDetails.ToolCalls[0].FileSearch)
+}
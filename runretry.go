@@ -0,0 +1,46 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// RunThreadWithRunRetry runs the assistant on the thread and waits for the run
+// like WaitForRun, starting a new run when it fails with a transient
+// server_error, up to maxRunRetries times. Retries of the HTTP requests don't
+// cover these failures, which happen after the run was accepted. It returns
+// the last run, along with an error when that run did not complete.
+func (c *Client) RunThreadWithRunRetry(ctx context.Context, threadID, assistantID string, maxRunRetries int, opts ...WaitOption) (_ *Run, err error) {
+	ctx, op := c.startOperation(ctx, "RunThreadWithRunRetry")
+	defer op.end(&err)
+
+	if maxRunRetries < 0 {
+		return nil, fmt.Errorf("max run retries %d cannot be negative", maxRunRetries)
+	}
+
+	cfg := newWaitConfig(opts)
+	for attempt := 0; ; attempt++ {
+		run, err := c.RunThread(ctx, threadID, assistantID)
+		if err != nil {
+			return nil, fmt.Errorf("could not run thread: %w", err)
+		}
+
+		last, err := c.waitForRun(ctx, threadID, run.ID, cfg)
+		if err == nil || !isRetriableRunFailure(last) || attempt == maxRunRetries {
+			return last, err
+		}
+
+		if c.logger != nil {
+			c.logger.Warn("Run failed on a server error, running again",
+				slog.String("runID", run.ID),
+				slog.Int("attempt", attempt+1),
+				slog.String("error", last.LastError.Message))
+		}
+	}
+}
+
+func isRetriableRunFailure(run *Run) bool {
+	return run != nil && run.Status == RunStatusFailed &&
+		run.LastError != nil && run.LastError.Code == RunErrorCodeServerError
+}
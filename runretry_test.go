@@ -0,0 +1,106 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RunThreadWithRunRetry(t *testing.T) {
+	t.Parallel()
+
+	serverError := &RunError{Code: RunErrorCodeServerError, Message: "Something went wrong"}
+
+	tests := []struct {
+		name          string
+		maxRunRetries int
+		outcomes      []*RunError // Last error of consecutive runs, nil when completed
+		wantRuns      int
+		wantStatus    string
+		expectError   bool
+	}{
+		{
+			name:          "completes first time",
+			maxRunRetries: 2,
+			outcomes:      []*RunError{nil},
+			wantRuns:      1,
+			wantStatus:    RunStatusCompleted,
+		},
+		{
+			name:          "completes after server errors",
+			maxRunRetries: 2,
+			outcomes:      []*RunError{serverError, serverError, nil},
+			wantRuns:      3,
+			wantStatus:    RunStatusCompleted,
+		},
+		{
+			name:          "retries exhausted",
+			maxRunRetries: 1,
+			outcomes:      []*RunError{serverError, serverError, nil},
+			wantRuns:      2,
+			wantStatus:    RunStatusFailed,
+			expectError:   true,
+		},
+		{
+			name:          "failure not retriable",
+			maxRunRetries: 2,
+			outcomes:      []*RunError{{Code: "rate_limit_exceeded", Message: "Quota exceeded"}, nil},
+			wantRuns:      1,
+			wantStatus:    RunStatusFailed,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu   sync.Mutex
+				runs int
+			)
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /threads/thread_123/runs", func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				runs++
+				id := fmt.Sprintf("run_%d", runs)
+				mu.Unlock()
+				json.NewEncoder(w).Encode(Run{ID: id, Status: RunStatusQueued})
+			})
+			mux.HandleFunc("GET /threads/thread_123/runs/{runID}", func(w http.ResponseWriter, r *http.Request) {
+				var n int
+				fmt.Sscanf(r.PathValue("runID"), "run_%d", &n)
+
+				run := Run{ID: r.PathValue("runID"), Status: RunStatusCompleted}
+				if lastErr := tt.outcomes[n-1]; lastErr != nil {
+					run.Status = RunStatusFailed
+					run.LastError = lastErr
+				}
+				json.NewEncoder(w).Encode(run)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			run, err := client.RunThreadWithRunRetry(context.Background(), "thread_123", "asst_123", tt.maxRunRetries, withoutSleep(nil, nil))
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantRuns, runs)
+			require.Equal(t, fmt.Sprintf("run_%d", tt.wantRuns), run.ID)
+			require.Equal(t, tt.wantStatus, run.Status)
+		})
+	}
+}
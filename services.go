@@ -0,0 +1,160 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// AssistantService groups the assistant endpoints.
+type AssistantService interface {
+	CreateAssistant(ctx context.Context, in *CreateAssistantInput) (*Assistant, error)
+	GetAssistant(ctx context.Context, assistantID string) (*Assistant, error)
+	GetAssistantVectorStores(ctx context.Context, assistantID string) ([]string, error)
+	ModifyAssistant(ctx context.Context, assistantID string, in *ModifyAssistantInput) (*Assistant, error)
+	ListAssistants(ctx context.Context, params ListParams) (*AssistantList, error)
+}
+
+// ThreadService groups the thread and message endpoints.
+type ThreadService interface {
+	CreateThread(ctx context.Context) (*Thread, error)
+	CreateThreadWithResources(ctx context.Context, resources *ToolResources) (*Thread, error)
+	GetThread(ctx context.Context, threadID string) (*Thread, error)
+	ModifyThread(ctx context.Context, threadID string, metadata Meta) (*Thread, error)
+	GetThreadVectorStores(ctx context.Context, threadID string) ([]string, error)
+	DeleteThread(ctx context.Context, threadID string) error
+	WaitUntilThreadIdle(ctx context.Context, threadID string, opts ...WaitOption) error
+	AddMessage(ctx context.Context, in CreateMessageInput) (*MessageContent, error)
+	GetMessage(ctx context.Context, threadID, messageID string) (*MessageContent, error)
+	GetMessages(ctx context.Context, threadID string, params ListParams) (*ThreadMessageList, error)
+	GetAllMessages(ctx context.Context, threadID string) ([]MessageContent, error)
+	StreamMessages(ctx context.Context, threadID string, fn func(MessageContent) error) error
+	DeleteMessage(ctx context.Context, threadID, messageID string) error
+	ClearThread(ctx context.Context, threadID string) (int, error)
+	ExportThread(ctx context.Context, threadID string) (*ThreadExport, error)
+	StreamThread(ctx context.Context, threadID, assistantID, userMessage string) (<-chan string, <-chan error)
+}
+
+// RunService groups the run endpoints.
+type RunService interface {
+	RunThread(ctx context.Context, threadID, assistantID string) (*Run, error)
+	RunThreadWithOptions(ctx context.Context, threadID, assistantID string, opts *RunOptions) (*Run, error)
+	CreateThreadAndRun(ctx context.Context, in CreateThreadAndRunInput) (*Run, error)
+	RunThreadStream(ctx context.Context, threadID, assistantID string) (<-chan StreamEvent, error)
+	CreateThreadAndRunStream(ctx context.Context, assistantID string, messages []ThreadMessage) (<-chan StreamEvent, error)
+	GetRun(ctx context.Context, threadID, runID string) (*Run, error)
+	RunThreadWithRunRetry(ctx context.Context, threadID, assistantID string, maxRunRetries int, opts ...WaitOption) (*Run, error)
+	WaitForRun(ctx context.Context, threadID, runID string, opts ...WaitOption) error
+	WaitForRunWithCallback(ctx context.Context, threadID, runID string, onStatus func(*Run), opts ...WaitOption) error
+	SubmitToolOutputs(ctx context.Context, threadID string, runID string, outputs []ToolOutput) error
+	SubmitToolOutputsAndWait(ctx context.Context, threadID, runID string, outputs []ToolOutput, handler ToolCallHandler) (*Run, error)
+	ListRuns(ctx context.Context, threadID string, params ListParams) (*RunList, error)
+	GetRunSteps(ctx context.Context, threadID, runID string) (*RunSteps, error)
+	GetRunStepsWithContent(ctx context.Context, threadID, runID string) (*RunSteps, error)
+}
+
+// FileService groups the file endpoints.
+type FileService interface {
+	ListFiles(ctx context.Context, purpose string, params ListParams) (*ListResponse, error)
+	UploadFile(ctx context.Context, data io.Reader, purpose, ext string) (*FileUploadResponse, error)
+	UploadFileNamed(ctx context.Context, data io.Reader, purpose, filename string) (*FileUploadResponse, error)
+	GetFileMetadata(ctx context.Context, fileID string) (*FileDetails, error)
+	GetFileContent(ctx context.Context, fileID string) ([]byte, error)
+	GetFileContentWithMeta(ctx context.Context, fileID string) (*FileContent, error)
+	DeleteFile(ctx context.Context, fileID string) error
+}
+
+// VectorStoreService groups the vector store endpoints.
+type VectorStoreService interface {
+	CreateVectorStore(ctx context.Context, in *CreateVectorStoreInput) (*VectorStore, error)
+	GetVectorStore(ctx context.Context, vectorStoreID string) (*VectorStore, error)
+	WaitForVectorStoreCompletion(ctx context.Context, vectorStoreID string, timeout, maxDelay time.Duration) error
+	ListVectorStores(ctx context.Context, params ListParams) (*VectorStoreList, error)
+	DeleteVectorStore(ctx context.Context, vectorStoreID string) error
+	CreateVectorStoreFile(ctx context.Context, vectorStoreID, fileID string) (*VectorStoreFile, error)
+	ListVectorStoreFiles(ctx context.Context, vectorStoreID string, params ListParams) (*VectorStoreFileList, error)
+	CreateVectorStoreFileBatch(ctx context.Context, vectorStoreID string, fileIDs []string) (*VectorStoreFileBatch, error)
+	GetVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string) (*VectorStoreFileBatch, error)
+	WaitForFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...WaitOption) (*VectorStoreFileBatch, error)
+}
+
+// AudioService groups the audio endpoints.
+type AudioService interface {
+	TranscribeAudio(ctx context.Context, in TranscribeAudioInput) ([]byte, error)
+	TranscribeAudioVerbose(ctx context.Context, in TranscribeAudioInput) (*Transcription, error)
+	CreateSpeech(ctx context.Context, in SpeechRequest) (io.ReadCloser, error)
+}
+
+// ChatService groups the chat completion endpoints.
+type ChatService interface {
+	CreateChatCompletion(ctx context.Context, in ChatCompletionRequest) (*ChatCompletionResponse, error)
+	CreateChatCompletionStream(ctx context.Context, in ChatCompletionRequest) (<-chan StreamEvent, error)
+}
+
+// EmbeddingService groups the embedding endpoints.
+type EmbeddingService interface {
+	CreateEmbeddings(ctx context.Context, in EmbeddingRequest) (*EmbeddingResponse, error)
+}
+
+// ModerationService groups the moderation endpoints.
+type ModerationService interface {
+	CreateModeration(ctx context.Context, input []string, model string) (*ModerationResponse, error)
+}
+
+// ImageService groups the image endpoints.
+type ImageService interface {
+	CreateImage(ctx context.Context, in ImageRequest) (*ImageResponse, error)
+}
+
+// ClientInterface is implemented by *Client. Depend on it, or on one of the
+// narrower service interfaces, to be able to substitute a fake in tests.
+type ClientInterface interface {
+	AssistantService
+	ThreadService
+	RunService
+	FileService
+	VectorStoreService
+	AudioService
+	ChatService
+	EmbeddingService
+	ModerationService
+	ImageService
+
+	Ask(ctx context.Context, assistantID, question string) (string, error)
+	Continue(ctx context.Context, threadID, assistantID, message string) (string, error)
+	CreateRAGAssistant(ctx context.Context, in RAGAssistantInput) (*Assistant, error)
+	ResolveCitationFileNames(ctx context.Context, citations []ResolvedCitation) error
+	Do(ctx context.Context, method, path string, in, out any, opts ...RequestOption) error
+}
+
+var _ ClientInterface = (*Client)(nil)
+
+// Assistants returns the assistant endpoints of the client.
+func (c *Client) Assistants() AssistantService { return c }
+
+// Threads returns the thread and message endpoints of the client.
+func (c *Client) Threads() ThreadService { return c }
+
+// Runs returns the run endpoints of the client.
+func (c *Client) Runs() RunService { return c }
+
+// Files returns the file endpoints of the client.
+func (c *Client) Files() FileService { return c }
+
+// VectorStores returns the vector store endpoints of the client.
+func (c *Client) VectorStores() VectorStoreService { return c }
+
+// Audio returns the audio endpoints of the client.
+func (c *Client) Audio() AudioService { return c }
+
+// Chat returns the chat completion endpoints of the client.
+func (c *Client) Chat() ChatService { return c }
+
+// Embeddings returns the embedding endpoints of the client.
+func (c *Client) Embeddings() EmbeddingService { return c }
+
+// Moderations returns the moderation endpoints of the client.
+func (c *Client) Moderations() ModerationService { return c }
+
+// Images returns the image endpoints of the client.
+func (c *Client) Images() ImageService { return c }
@@ -0,0 +1,59 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Limits of the speech the API synthesizes.
+const (
+	maxSpeechInputLen = 4096
+	minSpeechSpeed    = 0.25
+	maxSpeechSpeed    = 4.0
+)
+
+// CreateSpeech synthesizes the input text and returns the audio as it is
+// streamed by the API, so that long passages can be played or stored without
+// being buffered whole. The caller must close the returned reader.
+func (c *Client) CreateSpeech(ctx context.Context, in SpeechRequest) (_ io.ReadCloser, err error) {
+	ctx, op := c.startOperation(ctx, "CreateSpeech")
+	defer op.end(&err)
+
+	if in.Model == "" {
+		return nil, fmt.Errorf("model is required")
+	}
+
+	if in.Input == "" {
+		return nil, fmt.Errorf("input is required")
+	}
+
+	if len([]rune(in.Input)) > maxSpeechInputLen {
+		return nil, fmt.Errorf("input is longer than %d characters", maxSpeechInputLen)
+	}
+
+	if in.Voice == "" {
+		return nil, fmt.Errorf("voice is required")
+	}
+
+	if in.Speed != 0 && (in.Speed < minSpeechSpeed || in.Speed > maxSpeechSpeed) {
+		return nil, fmt.Errorf("speed %g is out of range, must be between %g and %g", in.Speed, minSpeechSpeed, maxSpeechSpeed)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/audio/speech", in)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doWithRetryHeaders(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not send request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+	return resp.Body, nil
+}
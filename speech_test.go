@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateSpeech(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        SpeechRequest
+		wantBody     string
+		serverBody   string
+		serverStatus int
+		expectError  bool
+	}{
+		{
+			name: "successful synthesis",
+			input: SpeechRequest{
+				Model:          "tts-1",
+				Input:          "Hello there",
+				Voice:          "alloy",
+				ResponseFormat: "opus",
+				Speed:          1.5,
+			},
+			wantBody:     `{"model":"tts-1","input":"Hello there","voice":"alloy","response_format":"opus","speed":1.5}`,
+			serverBody:   "fake audio data",
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:         "defaults",
+			input:        SpeechRequest{Model: "tts-1", Input: "Hello there", Voice: "nova"},
+			wantBody:     `{"model":"tts-1","input":"Hello there","voice":"nova"}`,
+			serverBody:   "fake audio data",
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:        "missing voice",
+			input:       SpeechRequest{Model: "tts-1", Input: "Hello there"},
+			expectError: true,
+		},
+		{
+			name:        "input too long",
+			input:       SpeechRequest{Model: "tts-1", Input: strings.Repeat("a", 4097), Voice: "alloy"},
+			expectError: true,
+		},
+		{
+			name:        "speed out of range",
+			input:       SpeechRequest{Model: "tts-1", Input: "Hello there", Voice: "alloy", Speed: 5},
+			expectError: true,
+		},
+		{
+			name:         "unknown voice",
+			input:        SpeechRequest{Model: "tts-1", Input: "Hello there", Voice: "robot"},
+			wantBody:     `{"model":"tts-1","input":"Hello there","voice":"robot"}`,
+			serverBody:   `{"error":{"message":"Invalid voice"}}`,
+			serverStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/audio/speech", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.wantBody, string(body))
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverBody))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			audio, err := client.CreateSpeech(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer audio.Close()
+
+			data, err := io.ReadAll(audio)
+			require.NoError(t, err)
+			require.Equal(t, tt.serverBody, string(data))
+		})
+	}
+}
+
+func TestClient_CreateSpeech_AttemptTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Stream the audio for longer than the attempt timeout
+		for i := 0; i < 4; i++ {
+			w.Write([]byte("chunk"))
+			w.(http.Flusher).Flush()
+			time.Sleep(30 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	client := New(slog.Default(), "test-key", server.Client(),
+		WithBaseURL(server.URL),
+		WithAttemptTimeout(50*time.Millisecond),
+	)
+
+	audio, err := client.CreateSpeech(context.Background(), SpeechRequest{Model: "tts-1", Input: "Hello there", Voice: "alloy"})
+	require.NoError(t, err)
+	defer audio.Close()
+
+	data, err := io.ReadAll(audio)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("chunk", 4), string(data))
+}
@@ -0,0 +1,177 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxStreamEventSize bounds the size of a single line of a server-sent events
+// stream, which must hold a whole event payload.
+const maxStreamEventSize = 1024 * 1024
+
+// RunThreadStream starts a run of the assistant on the thread and streams its
+// events. The channel is closed once the run is done. If the stream breaks
+// before that, a final event of type StreamEventError is sent before closing.
+func (c *Client) RunThreadStream(ctx context.Context, threadID, assistantID string) (_ <-chan StreamEvent, err error) {
+	ctx, op := c.startOperation(ctx, "RunThreadStream")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/threads/"+threadID+"/runs", struct {
+		AssistantID string `json:"assistant_id"`
+		Stream      bool   `json:"stream"`
+	}{
+		AssistantID: assistantID,
+		Stream:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	return c.stream(req)
+}
+
+// CreateThreadAndRunStream creates a thread with the given messages and
+// streams a run of the assistant on it, all in one request, which suits
+// stateless single-turn chats. The new thread's ID is in the data of the
+// thread.created event. The channel is closed like that of RunThreadStream.
+func (c *Client) CreateThreadAndRunStream(ctx context.Context, assistantID string, messages []ThreadMessage) (_ <-chan StreamEvent, err error) {
+	ctx, op := c.startOperation(ctx, "CreateThreadAndRunStream")
+	defer op.end(&err)
+
+	if assistantID == "" {
+		return nil, fmt.Errorf("assistant ID is required")
+	}
+
+	for i, msg := range messages {
+		if err := validateMessage(msg); err != nil {
+			return nil, fmt.Errorf("invalid message %d: %w", i, err)
+		}
+	}
+
+	type thread struct {
+		Messages []ThreadMessage `json:"messages,omitempty"`
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/threads/runs", struct {
+		AssistantID string `json:"assistant_id"`
+		Thread      thread `json:"thread"`
+		Stream      bool   `json:"stream"`
+	}{
+		AssistantID: assistantID,
+		Thread:      thread{Messages: messages},
+		Stream:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	return c.stream(req)
+}
+
+// stream sends a streaming request and relays the decoded events on the
+// returned channel.
+func (c *Client) stream(req *http.Request) (<-chan StreamEvent, error) {
+	return c.streamWith(req, nil)
+}
+
+// streamWith is like stream, but relays the events returned by expand for each
+// decoded event instead of the event itself, when expand is not nil.
+func (c *Client) streamWith(req *http.Request, expand func(StreamEvent) []StreamEvent) (<-chan StreamEvent, error) {
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not send request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+
+	ctx := req.Context()
+	events := make(chan StreamEvent)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		send := func(event StreamEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		relay := send
+		if expand != nil {
+			relay = func(event StreamEvent) bool {
+				for _, e := range expand(event) {
+					if !send(e) {
+						return false
+					}
+				}
+				return true
+			}
+		}
+
+		if err := readEvents(resp.Body, relay); err != nil {
+			data, _ := json.Marshal(struct {
+				Message string `json:"message"`
+			}{
+				Message: err.Error(),
+			})
+			send(StreamEvent{Event: StreamEventError, Data: data})
+		}
+	}()
+	return events, nil
+}
+
+// readEvents parses a server-sent events stream, calling fn with each event
+// until the [DONE] sentinel is received or fn returns false. A stream ending
+// without the sentinel is reported as an error.
+func readEvents(r io.Reader, fn func(StreamEvent) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamEventSize)
+
+	var (
+		event string
+		data  []string
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) == 0 {
+				event = ""
+				continue
+			}
+
+			payload := strings.Join(data, "\n")
+			if payload == "[DONE]" {
+				return nil
+			}
+
+			if !fn(StreamEvent{Event: event, Data: json.RawMessage(payload)}) {
+				return nil
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("could not read stream: %w", err)
+	}
+	return fmt.Errorf("stream ended before completion: %w", io.ErrUnexpectedEOF)
+}
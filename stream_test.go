@@ -0,0 +1,192 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RunThreadStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		serverStatus int
+		body         string
+		wantEvents   []string
+		expectError  bool
+	}{
+		{
+			name:         "streams events until done",
+			serverStatus: http.StatusOK,
+			body: "event: thread.run.created\n" +
+				"data: {\"id\":\"run_123\"}\n\n" +
+				"event: thread.message.delta\n" +
+				"data: {\"id\":\"msg_123\",\"delta\":{\"content\":[{\"type\":\"text\",\"text\":{\"value\":\"Hi\"}}]}}\n\n" +
+				"event: thread.run.completed\n" +
+				"data: {\"id\":\"run_123\"}\n\n" +
+				"event: done\n" +
+				"data: [DONE]\n\n",
+			wantEvents: []string{"thread.run.created", StreamEventMessageDelta, StreamEventRunCompleted},
+		},
+		{
+			name:         "stream cut short",
+			serverStatus: http.StatusOK,
+			body: "event: thread.run.created\n" +
+				"data: {\"id\":\"run_123\"}\n\n",
+			wantEvents: []string{"thread.run.created", StreamEventError},
+		},
+		{
+			name:         "request rejected",
+			serverStatus: http.StatusBadRequest,
+			body:         `{"error":{"message":"Thread already has an active run"}}`,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/thread_123/runs", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"assistant_id":"asst_123","stream":true}`, string(body))
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			events, err := client.RunThreadStream(context.Background(), "thread_123", "asst_123")
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for event := range events {
+				require.True(t, json.Valid(event.Data), "event data must be JSON: %s", event.Data)
+				got = append(got, event.Event)
+			}
+			require.Equal(t, tt.wantEvents, got)
+		})
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	t.Parallel()
+
+	stream := ": keep-alive comment\n\n" +
+		"data: {\"a\":1}\n\n" +
+		"event: multi\n" +
+		"data: {\"b\":\n" +
+		"data: 2}\n\n" +
+		"data: [DONE]\n\n" +
+		"data: {\"ignored\":true}\n\n"
+
+	var got []StreamEvent
+	err := readEvents(strings.NewReader(stream), func(event StreamEvent) bool {
+		got = append(got, event)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []StreamEvent{
+		{Data: json.RawMessage(`{"a":1}`)},
+		{Event: "multi", Data: json.RawMessage("{\"b\":\n2}")},
+	}, got)
+}
+
+func TestClient_CreateThreadAndRunStream(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		assistantID  string
+		messages     []ThreadMessage
+		serverStatus int
+		body         string
+		wantEvents   []string
+		expectError  bool
+	}{
+		{
+			name:         "streams events of the new thread",
+			assistantID:  "asst_123",
+			messages:     []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+			serverStatus: http.StatusOK,
+			body: "event: thread.created\n" +
+				"data: {\"id\":\"thread_123\"}\n\n" +
+				"event: thread.run.completed\n" +
+				"data: {\"id\":\"run_123\"}\n\n" +
+				"event: done\n" +
+				"data: [DONE]\n\n",
+			wantEvents: []string{"thread.created", StreamEventRunCompleted},
+		},
+		{
+			name:        "missing assistant ID",
+			messages:    []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+			expectError: true,
+		},
+		{
+			name:         "request rejected",
+			assistantID:  "asst_123",
+			messages:     []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+			serverStatus: http.StatusNotFound,
+			body:         `{"error":{"message":"No assistant found"}}`,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/runs", r.URL.Path)
+				require.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, `{"assistant_id":"asst_123","thread":{"messages":[{"role":"user","content":"Hello"}]},"stream":true}`, string(body))
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			events, err := client.CreateThreadAndRunStream(context.Background(), tt.assistantID, tt.messages)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for event := range events {
+				got = append(got, event.Event)
+			}
+			require.Equal(t, tt.wantEvents, got)
+		})
+	}
+}
@@ -2,50 +2,130 @@ package openai
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
-
-	"github.com/wiselead-ai/httpclient"
 )
 
-func (c *Client) CreateThread(ctx context.Context) (*Thread, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/threads", c.baseURL),
-		bytes.NewBuffer([]byte("{}")),
-	)
+func (c *Client) CreateThread(ctx context.Context) (_ *Thread, err error) {
+	ctx, op := c.startOperation(ctx, "CreateThread")
+	defer op.end(&err)
+
+	return c.CreateThreadWithResources(ctx, nil)
+}
+
+// CreateThreadWithResources creates a thread whose tools use the given
+// resources, such as vector stores for file search, on top of the assistant's.
+func (c *Client) CreateThreadWithResources(ctx context.Context, resources *ToolResources) (_ *Thread, err error) {
+	ctx, op := c.startOperation(ctx, "CreateThreadWithResources")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/threads", struct {
+		ToolResources *ToolResources `json:"tool_resources,omitempty"`
+	}{
+		ToolResources: resources,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	var thread Thread
+	if err := c.sendRequest(req, &thread); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
+// GetThread retrieves the thread, including its metadata and tool resources.
+func (c *Client) GetThread(ctx context.Context, threadID string) (_ *Thread, err error) {
+	ctx, op := c.startOperation(ctx, "GetThread")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/threads/"+threadID, nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: '%d', response: '%s'", resp.StatusCode, string(b))
+	var thread Thread
+	if err := c.sendRequest(req, &thread); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// ModifyThread replaces the metadata of the thread and returns the updated
+// thread.
+func (c *Client) ModifyThread(ctx context.Context, threadID string, metadata Meta) (_ *Thread, err error) {
+	ctx, op := c.startOperation(ctx, "ModifyThread")
+	defer op.end(&err)
+
+	if threadID == "" {
+		return nil, fmt.Errorf("thread ID is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/threads/"+threadID, struct {
+		Metadata Meta `json:"metadata"`
+	}{
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	var thread Thread
-	if err := json.NewDecoder(resp.Body).Decode(&thread); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if err := c.sendRequest(req, &thread); err != nil {
+		return nil, err
 	}
 	return &thread, nil
 }
+
+// GetThreadVectorStores returns the IDs of the vector stores the thread uses
+// for file search, which is empty when the thread relies only on the
+// assistant's vector stores.
+func (c *Client) GetThreadVectorStores(ctx context.Context, threadID string) (_ []string, err error) {
+	ctx, op := c.startOperation(ctx, "GetThreadVectorStores")
+	defer op.end(&err)
+
+	thread, err := c.GetThread(ctx, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get thread: %w", err)
+	}
+
+	if thread.ToolResources == nil || thread.ToolResources.FileSearch == nil {
+		return nil, nil
+	}
+	return thread.ToolResources.FileSearch.VectorStoreIDs, nil
+}
+
+// DeleteThread deletes the thread together with its messages.
+func (c *Client) DeleteThread(ctx context.Context, threadID string) (err error) {
+	ctx, op := c.startOperation(ctx, "DeleteThread")
+	defer op.end(&err)
+
+	if threadID == "" {
+		return fmt.Errorf("thread ID is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodDelete, "/threads/"+threadID, nil)
+	if err != nil {
+		return err
+	}
+
+	var status DeletionStatus
+	if err := c.sendRequest(req, &status); err != nil {
+		return err
+	}
+
+	if !status.Deleted {
+		return fmt.Errorf("thread '%s' was not deleted", threadID)
+	}
+	return nil
+}
+
 func (c *Client) StreamThread(ctx context.Context, threadID, assistantID, userMessage string) (<-chan string, <-chan error) {
 	textChan := make(chan string)
 	errChan := make(chan error, 1)
@@ -54,7 +134,7 @@ func (c *Client) StreamThread(ctx context.Context, threadID, assistantID, userMe
 		defer close(textChan)
 		defer close(errChan)
 
-		if err := c.AddMessage(ctx, CreateMessageInput{
+		if _, err := c.AddMessage(ctx, CreateMessageInput{
 			ThreadID: threadID,
 			Message: ThreadMessage{
 				Role:    RoleUser,
@@ -65,38 +145,29 @@ func (c *Client) StreamThread(ctx context.Context, threadID, assistantID, userMe
 			return
 		}
 
-		jsonData, err := json.Marshal(map[string]interface{}{
+		req, err := c.newRequest(ctx, http.MethodPost, "/threads/"+threadID+"/runs", map[string]interface{}{
 			"assistant_id": assistantID,
 			"stream":       true,
 		})
 		if err != nil {
-			errChan <- fmt.Errorf("could not marshal run request: %w", err)
-			return
-		}
-
-		req, err := http.NewRequestWithContext(
-			ctx,
-			http.MethodPost,
-			fmt.Sprintf("%s/threads/%s/runs", c.baseURL, threadID),
-			bytes.NewBuffer(jsonData),
-		)
-		if err != nil {
-			errChan <- fmt.Errorf("could not create request: %w", err)
+			errChan <- err
 			return
 		}
 
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
-		req.Header.Set("OpenAI-Beta", "assistants=v2")
 		req.Header.Set("Accept", "text/event-stream")
 
-		resp, err := c.httpClient.Do(req)
+		resp, err := c.do(req)
 		if err != nil {
 			errChan <- fmt.Errorf("could not send request: %w", err)
 			return
 		}
 		defer resp.Body.Close()
 
+		if resp.StatusCode != http.StatusOK {
+			errChan <- newAPIError(resp)
+			return
+		}
+
 		scanner := bufio.NewScanner(resp.Body)
 		for scanner.Scan() {
 			line := scanner.Text()
@@ -139,219 +210,585 @@ func (c *Client) StreamThread(ctx context.Context, threadID, assistantID, userMe
 	return textChan, errChan
 }
 
-func (c *Client) AddMessage(ctx context.Context, in CreateMessageInput) error {
-	jsonData, err := json.Marshal(in.Message)
-	if err != nil {
-		return fmt.Errorf("could not marshal message: %w", err)
+// AddMessage adds the message to the thread and returns the created message,
+// which carries its server-assigned ID. When the API rejects the message
+// because a run is active on the thread, AddMessage waits for the thread to be
+// idle and sends the message once more, with its full body.
+func (c *Client) AddMessage(ctx context.Context, in CreateMessageInput) (_ *MessageContent, err error) {
+	ctx, op := c.startOperation(ctx, "AddMessage")
+	defer op.end(&err)
+
+	if in.ThreadID == "" {
+		return nil, fmt.Errorf("thread ID is required")
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/threads/%s/messages", c.baseURL, in.ThreadID),
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return fmt.Errorf("could not create request: %w", err)
+	if err := validateMessage(in.Message); err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	send := func() (*http.Response, error) {
+		req, err := c.newRequest(ctx, http.MethodPost, "/threads/"+in.ThreadID+"/messages", in.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.doWithRetry(req)
+		if err != nil {
+			return nil, fmt.Errorf("could not send request: %w", err)
+		}
+		return resp, nil
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := send()
 	if err != nil {
-		return fmt.Errorf("could not send request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(resp.Body)
-		if strings.Contains(string(b), "Can't add messages to thread") {
-			time.Sleep(5 * time.Second)
-			resp, err = c.httpClient.Do(req)
-			if err != nil {
-				return fmt.Errorf("could not send request: %w", err)
-			}
-			defer resp.Body.Close()
-			if resp.StatusCode != http.StatusOK {
-				b, _ := io.ReadAll(resp.Body)
-				return fmt.Errorf("unexpected status code: '%d', response: '%s'", resp.StatusCode, string(b))
-			}
-		} else {
-			return fmt.Errorf("unexpected status code: '%d', response: '%s'", resp.StatusCode, string(b))
+	if resp.StatusCode == http.StatusBadRequest {
+		// The API rejects new messages while a run is active on the thread.
+		// Wait the run out and try once more; any other bad request is final.
+		apiErr := newAPIError(resp)
+		if run, err := c.activeRun(ctx, in.ThreadID); err != nil || run == nil {
+			return nil, apiErr
+		}
+
+		if err := c.WaitUntilThreadIdle(ctx, in.ThreadID); err != nil {
+			return nil, fmt.Errorf("could not wait for thread to become idle: %w", err)
+		}
+
+		resp, err = send()
+		if err != nil {
+			return nil, err
 		}
+		defer resp.Body.Close()
 	}
-	return nil
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var message MessageContent
+	if err := json.NewDecoder(resp.Body).Decode(&message); err != nil {
+		return nil, fmt.Errorf("could not decode response: %w", err)
+	}
+	return &message, nil
 }
 
-func (c *Client) GetMessages(ctx context.Context, threadID string) (*ThreadMessageList, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/threads/%s/messages", c.baseURL, threadID),
-		nil,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+// GetMessage retrieves a single message of the thread.
+func (c *Client) GetMessage(ctx context.Context, threadID, messageID string) (_ *MessageContent, err error) {
+	ctx, op := c.startOperation(ctx, "GetMessage")
+	defer op.end(&err)
+
+	if threadID == "" {
+		return nil, fmt.Errorf("thread ID is required")
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	if messageID == "" {
+		return nil, fmt.Errorf("message ID is required")
+	}
 
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/messages/%s", threadID, messageID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	var message MessageContent
+	if err := c.sendRequest(req, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// GetMessages retrieves a page of the messages of the thread, newest first
+// unless params.Order says otherwise. Use params.After with the LastID of the
+// previous page while HasMore is set to fetch the next one, or GetAllMessages
+// to fetch them all.
+func (c *Client) GetMessages(ctx context.Context, threadID string, params ListParams) (_ *ThreadMessageList, err error) {
+	ctx, op := c.startOperation(ctx, "GetMessages")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/messages%s", threadID, params.query()), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	var messages ThreadMessageList
-	if err := json.NewDecoder(resp.Body).Decode(&messages); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if err := c.sendRequest(req, &messages); err != nil {
+		return nil, err
 	}
 	return &messages, nil
 }
 
-func (c *Client) RunThread(ctx context.Context, threadID, assistantID string) (*Run, error) {
-	jsonData, err := json.Marshal(struct {
+func (c *Client) DeleteMessage(ctx context.Context, threadID, messageID string) (err error) {
+	ctx, op := c.startOperation(ctx, "DeleteMessage")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/threads/%s/messages/%s", threadID, messageID), nil)
+	if err != nil {
+		return err
+	}
+
+	var status DeletionStatus
+	if err := c.sendRequest(req, &status); err != nil {
+		return err
+	}
+
+	if !status.Deleted {
+		return fmt.Errorf("message '%s' was not deleted", messageID)
+	}
+	return nil
+}
+
+// ClearThread deletes every message of the thread while keeping the thread
+// itself, and returns the number of messages removed.
+func (c *Client) ClearThread(ctx context.Context, threadID string) (_ int, err error) {
+	ctx, op := c.startOperation(ctx, "ClearThread")
+	defer op.end(&err)
+
+	var deleted int
+	for {
+		messages, err := c.GetMessages(ctx, threadID, ListParams{Limit: messagePageSize})
+		if err != nil {
+			return deleted, fmt.Errorf("could not get messages: %w", err)
+		}
+
+		if len(messages.Data) == 0 {
+			return deleted, nil
+		}
+
+		for _, msg := range messages.Data {
+			if err := c.DeleteMessage(ctx, threadID, msg.ID); err != nil {
+				return deleted, fmt.Errorf("could not delete message '%s': %w", msg.ID, err)
+			}
+			deleted++
+		}
+	}
+}
+
+func (c *Client) RunThread(ctx context.Context, threadID, assistantID string) (_ *Run, err error) {
+	ctx, op := c.startOperation(ctx, "RunThread")
+	defer op.end(&err)
+
+	return c.RunThreadWithOptions(ctx, threadID, assistantID, nil)
+}
+
+// RunThreadWithOptions starts a run like RunThread, overriding the run
+// parameters set in opts. A nil opts behaves like RunThread.
+func (c *Client) RunThreadWithOptions(ctx context.Context, threadID, assistantID string, opts *RunOptions) (_ *Run, err error) {
+	ctx, op := c.startOperation(ctx, "RunThreadWithOptions")
+	defer op.end(&err)
+
+	if err := validateRunOptions(opts); err != nil {
+		return nil, fmt.Errorf("invalid run options: %w", err)
+	}
+
+	if opts != nil && opts.WaitForIdle {
+		if err := c.WaitUntilThreadIdle(ctx, threadID); err != nil {
+			return nil, fmt.Errorf("could not wait for thread to become idle: %w", err)
+		}
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/threads/"+threadID+"/runs", struct {
 		AssistantID string `json:"assistant_id"`
+		*RunOptions
 	}{
 		AssistantID: assistantID,
+		RunOptions:  opts,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal run input: %w", err)
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/threads/%s/runs", c.baseURL, threadID),
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+	var run Run
+	if err := c.sendRequest(req, &run); err != nil {
+		return nil, err
 	}
+	return &run, nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+// CreateThreadAndRun creates a thread with the given messages and starts a run
+// on it in one request, which saves the round trips of CreateThread, AddMessage
+// and RunThread when the thread is not reused. The new thread's ID is in the
+// returned run's ThreadID.
+func (c *Client) CreateThreadAndRun(ctx context.Context, in CreateThreadAndRunInput) (_ *Run, err error) {
+	ctx, op := c.startOperation(ctx, "CreateThreadAndRun")
+	defer op.end(&err)
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
-	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+	if in.AssistantID == "" {
+		return nil, fmt.Errorf("assistant ID is required")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		responseBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(responseBody))
+	for i, msg := range in.Messages {
+		if err := validateMessage(msg); err != nil {
+			return nil, fmt.Errorf("invalid message %d: %w", i, err)
+		}
+	}
+
+	if err := validateRunOptions(in.Options); err != nil {
+		return nil, fmt.Errorf("invalid run options: %w", err)
+	}
+
+	type thread struct {
+		Messages []ThreadMessage `json:"messages,omitempty"`
+		Metadata Meta            `json:"metadata,omitempty"`
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/threads/runs", struct {
+		AssistantID string `json:"assistant_id"`
+		Thread      thread `json:"thread"`
+		*RunOptions
+	}{
+		AssistantID: in.AssistantID,
+		Thread:      thread{Messages: in.Messages, Metadata: in.Metadata},
+		RunOptions:  in.Options,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	var run Run
-	if err := json.NewDecoder(resp.Body).Decode(&run); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+	if err := c.sendRequest(req, &run); err != nil {
+		return nil, err
 	}
 	return &run, nil
 }
 
-// Add this new method to handle tool outputs
-func (c *Client) SubmitToolOutputs(ctx context.Context, threadID string, runID string, outputs []ToolOutput) error {
+func validateRunOptions(opts *RunOptions) error {
+	if opts == nil {
+		return nil
+	}
+
+	if err := validateTemperature(opts.Temperature); err != nil {
+		return err
+	}
+
+	if err := validateMetadata(opts.Metadata); err != nil {
+		return err
+	}
+
+	if ts := opts.TruncationStrategy; ts != nil {
+		switch ts.Type {
+		case TruncationAuto:
+		case TruncationLastMessages:
+			if ts.LastMessages < 1 {
+				return fmt.Errorf("truncation strategy '%s' requires last_messages to be at least 1", ts.Type)
+			}
+		default:
+			return fmt.Errorf("unknown truncation strategy '%s'", ts.Type)
+		}
+	}
+	return nil
+}
+
+// Limits of the metadata the API accepts on its objects.
+const (
+	maxMetadataKeys     = 16
+	maxMetadataKeyLen   = 64
+	maxMetadataValueLen = 512
+)
+
+func validateMetadata(m Meta) error {
+	if len(m) > maxMetadataKeys {
+		return fmt.Errorf("metadata has %d keys, at most %d are allowed", len(m), maxMetadataKeys)
+	}
+
+	for key, value := range m {
+		if len(key) > maxMetadataKeyLen {
+			return fmt.Errorf("metadata key '%s' is longer than %d characters", key, maxMetadataKeyLen)
+		}
+
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("metadata value of '%s' must be a string", key)
+		}
+		if len(s) > maxMetadataValueLen {
+			return fmt.Errorf("metadata value of '%s' is longer than %d characters", key, maxMetadataValueLen)
+		}
+	}
+	return nil
+}
+
+// SubmitToolOutputs submits the outputs of the tool calls the run requires.
+// The outputs are checked against the run's required tool calls first, so that
+// a duplicate, missing or unexpected tool call ID is reported precisely rather
+// than as a generic bad request.
+func (c *Client) SubmitToolOutputs(ctx context.Context, threadID string, runID string, outputs []ToolOutput) (err error) {
+	ctx, op := c.startOperation(ctx, "SubmitToolOutputs")
+	defer op.end(&err)
+
+	run, err := c.GetRun(ctx, threadID, runID)
+	if err != nil {
+		return fmt.Errorf("could not get run: %w", err)
+	}
+
+	var required []ToolCall
+	if run.RequiredAction != nil {
+		required = run.RequiredAction.ToolCalls
+	}
+	return c.submitToolOutputs(ctx, threadID, runID, outputs, required)
+}
+
+// submitToolOutputs checks the outputs against the required tool calls the
+// caller already holds and submits them, sparing the request for the run.
+func (c *Client) submitToolOutputs(ctx context.Context, threadID, runID string, outputs []ToolOutput, required []ToolCall) error {
+	if err := validateToolOutputs(outputs, required); err != nil {
+		return err
+	}
+
 	input := struct {
 		ToolOutputs []ToolOutput `json:"tool_outputs"`
 	}{
 		ToolOutputs: outputs,
 	}
 
-	jsonData, err := json.Marshal(input)
+	req, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/threads/%s/runs/%s/submit_tool_outputs", threadID, runID), input)
 	if err != nil {
-		return fmt.Errorf("could not marshal tool outputs: %w", err)
+		return err
 	}
+	return c.sendRequest(req, nil)
+}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodPost,
-		fmt.Sprintf("%s/threads/%s/runs/%s/submit_tool_outputs", c.baseURL, threadID, runID),
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return fmt.Errorf("could not create request: %w", err)
-	}
+func (c *Client) GetRun(ctx context.Context, threadID, runID string) (_ *Run, err error) {
+	ctx, op := c.startOperation(ctx, "GetRun")
+	defer op.end(&err)
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	run, _, err := c.getRun(ctx, threadID, runID, "")
+	return run, err
+}
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
+// getRun fetches the run, sending etag as If-None-Match when set. It returns
+// a nil run when the server answers that the run has not changed, and the
+// ETag of the response otherwise, which is empty if the server sent none.
+func (c *Client) getRun(ctx context.Context, threadID, runID, etag string) (*Run, string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/runs/%s", threadID, runID), nil)
 	if err != nil {
-		return fmt.Errorf("could not send request: %w", err)
+		return nil, "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
 	}
-	return nil
-}
 
-func (c *Client) GetRun(ctx context.Context, threadID, runID string) (*Run, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/threads/%s/runs/%s", c.baseURL, threadID, runID),
-		nil,
-	)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+		return nil, "", fmt.Errorf("could not send request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	if etag != "" && resp.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
-	if err != nil {
-		return nil, fmt.Errorf("could not send request: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", newAPIError(resp)
 	}
-	defer resp.Body.Close()
 
 	var run Run
 	if err := json.NewDecoder(resp.Body).Decode(&run); err != nil {
-		return nil, fmt.Errorf("could not decode response: %w", err)
+		return nil, "", fmt.Errorf("could not decode response: %w", err)
 	}
-	return &run, nil
+	return &run, resp.Header.Get("ETag"), nil
+}
+
+// runExpiryWarning is how close to its expiry a pending run must be before
+// WaitForRun warns about it.
+const runExpiryWarning = time.Minute
+
+// WaitForRun polls the run until it reaches a terminal status, waiting one
+// second between polls unless opts say otherwise.
+func (c *Client) WaitForRun(ctx context.Context, threadID, runID string, opts ...WaitOption) (err error) {
+	ctx, op := c.startOperation(ctx, "WaitForRun")
+	defer op.end(&err)
+
+	_, err = c.waitForRun(ctx, threadID, runID, newWaitConfig(opts))
+	return err
 }
 
-func (c *Client) WaitForRun(ctx context.Context, threadID, runID string) error {
+// WaitForRunWithCallback waits like WaitForRun and invokes onStatus with the
+// run every time its status changes, including the first status observed.
+func (c *Client) WaitForRunWithCallback(ctx context.Context, threadID, runID string, onStatus func(*Run), opts ...WaitOption) (err error) {
+	ctx, op := c.startOperation(ctx, "WaitForRunWithCallback")
+	defer op.end(&err)
+
+	cfg := newWaitConfig(opts)
+	cfg.onStatus = onStatus
+	_, err = c.waitForRun(ctx, threadID, runID, cfg)
+	return err
+}
+
+// waitForRun polls the run until it completes, returning the last run seen
+// along with an error when the run ended unsuccessfully.
+func (c *Client) waitForRun(ctx context.Context, threadID, runID string, cfg waitConfig) (*Run, error) {
+	var (
+		warnedExpiry bool
+		lastStatus   string
+		lastHandled  string
+		run          *Run
+		etag         string
+		interval     = cfg.interval
+	)
+
+	// cancelled reports the context's error, marking it when the run was
+	// left waiting for tool outputs.
+	cancelled := func(err error) error {
+		if lastStatus == RunStatusRequiresAction {
+			return fmt.Errorf("%w: %w", ErrRunRequiresAction, err)
+		}
+		return err
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, cancelled(ctx.Err())
 		default:
-			run, err := c.GetRun(ctx, threadID, runID)
+			// Polls send the ETag of the previous response, so that an
+			// unchanged run is neither transferred nor decoded again.
+			latest, latestETag, err := c.getRun(ctx, threadID, runID, etag)
 			if err != nil {
-				return fmt.Errorf("failed to get run: %w", err)
+				if ctx.Err() != nil {
+					return nil, cancelled(ctx.Err())
+				}
+				return nil, fmt.Errorf("failed to get run: %w", err)
+			}
+			if latest != nil {
+				run = latest
+			}
+			etag = latestETag
+
+			if cfg.onStatus != nil && run.Status != lastStatus {
+				cfg.onStatus(run)
 			}
+			lastStatus = run.Status
 
 			switch run.Status {
 			case RunStatusCompleted:
-				return nil
+				return run, nil
 			case RunStatusFailed:
+				if isContentFiltered(run) {
+					return run, fmt.Errorf("run failed: %w", ErrContentFiltered)
+				}
 				if run.LastError != nil {
-					return fmt.Errorf("run failed: %s - %s", run.LastError.Code, run.LastError.Message)
+					return run, fmt.Errorf("run failed: %s - %s", run.LastError.Code, run.LastError.Message)
+				}
+				return run, fmt.Errorf("run failed without error details")
+			case RunStatusIncomplete:
+				if isContentFiltered(run) {
+					return run, fmt.Errorf("run incomplete: %w", ErrContentFiltered)
+				}
+				if run.IncompleteDetails != nil {
+					return run, fmt.Errorf("run incomplete: %s", run.IncompleteDetails.Reason)
 				}
-				return fmt.Errorf("run failed without error details")
-			case RunStatusCancelled, RunStatusExpired:
-				return fmt.Errorf("run ended with status: %s", run.Status)
-			case RunStatusQueued, RunStatusInProgress, RunStatusRequiresAction:
-				time.Sleep(time.Second)
+				return run, fmt.Errorf("run ended with status: %s", run.Status)
+			case RunStatusExpired:
+				if run.ExpiresAt > 0 {
+					expiresAt := time.Unix(run.ExpiresAt, 0)
+					return run, fmt.Errorf("run expired at %s (%s ago)",
+						expiresAt.UTC().Format(time.RFC3339), time.Since(expiresAt).Round(time.Second))
+				}
+				return run, fmt.Errorf("run ended with status: %s", run.Status)
+			case RunStatusCancelled:
+				return run, fmt.Errorf("run ended with status: %s", run.Status)
+			case RunStatusQueued, RunStatusPending, RunStatusInProgress, RunStatusCancelling, RunStatusRequiresAction:
+				if run.Status == RunStatusRequiresAction {
+					if cfg.stopOnAction && requiresNewOutputs(run, cfg.answered) {
+						return run, nil
+					}
+					if cfg.toolHandler != nil && run.RequiredAction != nil && len(run.RequiredAction.ToolCalls) > 0 &&
+						run.RequiredAction.ToolCalls[0].ID != lastHandled {
+						if err := c.handleToolCalls(ctx, threadID, runID, run.RequiredAction.ToolCalls, cfg.toolHandler); err != nil {
+							return run, err
+						}
+						lastHandled = run.RequiredAction.ToolCalls[0].ID
+						continue
+					}
+				}
+				if !warnedExpiry && run.ExpiresAt > 0 {
+					if expiresIn := time.Until(time.Unix(run.ExpiresAt, 0)); expiresIn < runExpiryWarning {
+						c.logger.Warn("Run is about to expire",
+							slog.String("runID", runID),
+							slog.String("status", run.Status),
+							slog.Duration("expiresIn", expiresIn))
+						warnedExpiry = true
+					}
+				}
+				if err := cfg.sleep(ctx, interval); err != nil {
+					return nil, cancelled(err)
+				}
+				interval = cfg.nextInterval(interval)
 				continue
 			default:
-				return fmt.Errorf("unknown run status: %s", run.Status)
+				return run, fmt.Errorf("unknown run status: %s", run.Status)
 			}
 		}
 	}
 }
+
+// WaitUntilThreadIdle blocks until the latest run of the thread has reached a
+// terminal status, so that the thread accepts mutations again. It returns
+// immediately when the thread has no runs. A run that requires action stays
+// active until its tool outputs are submitted or it expires. The latest run is
+// polled every second unless opts say otherwise.
+func (c *Client) WaitUntilThreadIdle(ctx context.Context, threadID string, opts ...WaitOption) (err error) {
+	ctx, op := c.startOperation(ctx, "WaitUntilThreadIdle")
+	defer op.end(&err)
+
+	if threadID == "" {
+		return fmt.Errorf("thread ID is required")
+	}
+
+	cfg := newWaitConfig(opts)
+	interval := cfg.interval
+	for {
+		run, err := c.activeRun(ctx, threadID)
+		if err != nil {
+			return err
+		}
+
+		if run == nil {
+			return nil
+		}
+
+		if err := cfg.sleep(ctx, interval); err != nil {
+			return err
+		}
+		interval = cfg.nextInterval(interval)
+	}
+}
+
+// activeRun returns the latest run of the thread when it has not reached a
+// terminal status yet, or nil when the thread is idle.
+func (c *Client) activeRun(ctx context.Context, threadID string) (*Run, error) {
+	runs, err := c.ListRuns(ctx, threadID, ListParams{Limit: 1, Order: OrderDesc})
+	if err != nil {
+		return nil, fmt.Errorf("could not list runs: %w", err)
+	}
+
+	if len(runs.Data) == 0 || isTerminalRunStatus(runs.Data[0].Status) {
+		return nil, nil
+	}
+	return &runs.Data[0], nil
+}
+
+// requiresNewOutputs reports whether the run requires outputs for tool calls
+// other than the answered ones, or doesn't say which calls it requires.
+func requiresNewOutputs(run *Run, answered map[string]bool) bool {
+	if run.RequiredAction == nil || len(run.RequiredAction.ToolCalls) == 0 {
+		return true
+	}
+	for _, call := range run.RequiredAction.ToolCalls {
+		if !answered[call.ID] {
+			return true
+		}
+	}
+	return false
+}
+
+func isTerminalRunStatus(status string) bool {
+	switch status {
+	case RunStatusCompleted, RunStatusFailed, RunStatusCancelled, RunStatusExpired, RunStatusIncomplete:
+		return true
+	default:
+		return false
+	}
+}
@@ -3,11 +3,15 @@ package openai
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -59,6 +63,7 @@ func TestClient_CreateThread(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+			client.retrySleep = withoutRetryDelay(nil, nil)
 
 			result, err := client.CreateThread(context.Background())
 			if tt.expectError {
@@ -72,16 +77,231 @@ func TestClient_CreateThread(t *testing.T) {
 	}
 }
 
+func TestClient_CreateThreadWithResources(t *testing.T) {
+	t.Parallel()
+
+	resources := &ToolResources{
+		CodeInterpreter: &CodeInterpreter{FileIDs: []string{"file-123"}},
+		FileSearch:      &FileSearch{VectorStoreIDs: []string{"vs_123"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/threads", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+
+		var input struct {
+			ToolResources *ToolResources `json:"tool_resources"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		require.Equal(t, resources, input.ToolResources)
+
+		json.NewEncoder(w).Encode(Thread{ID: "thread_123", Object: "thread", ToolResources: input.ToolResources})
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	thread, err := client.CreateThreadWithResources(context.Background(), resources)
+	require.NoError(t, err)
+	require.Equal(t, "thread_123", thread.ID)
+	require.Equal(t, resources, thread.ToolResources)
+}
+
+func TestClient_GetThread(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		threadID       string
+		serverResponse *Thread
+		serverStatus   int
+		expectError    bool
+	}{
+		{
+			name:     "successful retrieval",
+			threadID: "thread_123",
+			serverResponse: &Thread{
+				ID:        "thread_123",
+				Object:    "thread",
+				CreatedAt: 1699009709,
+				Metadata:  map[string]any{"session": "abc"},
+				ToolResources: &ToolResources{
+					FileSearch: &FileSearch{VectorStoreIDs: []string{"vs_123"}},
+				},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:         "not found",
+			threadID:     "thread_nonexistent",
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/"+tt.threadID, r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.GetThread(context.Background(), tt.threadID)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_ModifyThread(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		threadID       string
+		metadata       Meta
+		serverResponse *Thread
+		serverStatus   int
+		expectError    bool
+	}{
+		{
+			name:     "successful modification",
+			threadID: "thread_123",
+			metadata: Meta{"session": "abc", "step": "checkout"},
+			serverResponse: &Thread{
+				ID:        "thread_123",
+				Object:    "thread",
+				CreatedAt: 1699009709,
+				Metadata:  Meta{"session": "abc", "step": "checkout"},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:         "not found",
+			threadID:     "thread_nonexistent",
+			metadata:     Meta{"session": "abc"},
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+		{
+			name:        "empty thread ID",
+			metadata:    Meta{"session": "abc"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/"+tt.threadID, r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				var input struct {
+					Metadata Meta `json:"metadata"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+				require.Equal(t, tt.metadata, input.Metadata)
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.ModifyThread(context.Background(), tt.threadID, tt.metadata)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_GetThreadVectorStores(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		thread Thread
+		want   []string
+	}{
+		{
+			name: "thread with vector stores",
+			thread: Thread{
+				ID: "thread_123",
+				ToolResources: &ToolResources{
+					FileSearch: &FileSearch{VectorStoreIDs: []string{"vs_1", "vs_2"}},
+				},
+			},
+			want: []string{"vs_1", "vs_2"},
+		},
+		{
+			name:   "thread without tool resources",
+			thread: Thread{ID: "thread_123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/thread_123", r.URL.Path)
+				json.NewEncoder(w).Encode(tt.thread)
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			got, err := client.GetThreadVectorStores(context.Background(), "thread_123")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestClient_AddMessage(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		name         string
-		input        CreateMessageInput
-		serverStatus int
-		retryCase    bool
-		expectError  bool
-		responses    []int // Multiple response codes for retry scenarios
+		name        string
+		input       CreateMessageInput
+		responses   []int    // Status codes returned for consecutive message posts
+		runStatuses []string // Latest run status for consecutive run lookups
+		expectPosts int
+		expectError bool
 	}{
 		{
 			name: "successful message addition",
@@ -92,10 +312,11 @@ func TestClient_AddMessage(t *testing.T) {
 					Content: "Hello",
 				},
 			},
-			serverStatus: http.StatusOK,
+			responses:   []int{http.StatusOK},
+			expectPosts: 1,
 		},
 		{
-			name: "retry successful",
+			name: "retry after active run",
 			input: CreateMessageInput{
 				ThreadID: "thread_123",
 				Message: ThreadMessage{
@@ -103,16 +324,69 @@ func TestClient_AddMessage(t *testing.T) {
 					Content: "Retry me",
 				},
 			},
-			retryCase:    true,
-			serverStatus: http.StatusOK,
+			responses:   []int{http.StatusBadRequest, http.StatusOK},
+			runStatuses: []string{RunStatusInProgress, RunStatusCompleted},
+			expectPosts: 2,
 		},
 		{
-			name: "retry exhausted",
+			name: "retry after server error",
+			input: CreateMessageInput{
+				ThreadID: "thread_123",
+				Message:  ThreadMessage{Role: RoleUser, Content: "Replay me"},
+			},
+			responses:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectPosts: 2,
+		},
+		{
+			name: "bad request without active run",
 			input: CreateMessageInput{
 				ThreadID: "thread_123",
 				Message:  ThreadMessage{Role: RoleUser, Content: "test"},
 			},
-			responses:   []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest},
+			responses:   []int{http.StatusBadRequest},
+			runStatuses: []string{RunStatusCompleted},
+			expectPosts: 1,
+			expectError: true,
+		},
+		{
+			name: "retry still rejected",
+			input: CreateMessageInput{
+				ThreadID: "thread_123",
+				Message:  ThreadMessage{Role: RoleUser, Content: "test"},
+			},
+			responses:   []int{http.StatusBadRequest, http.StatusBadRequest},
+			runStatuses: []string{RunStatusQueued, RunStatusCancelled},
+			expectPosts: 2,
+			expectError: true,
+		},
+		{
+			name: "image with detail",
+			input: CreateMessageInput{
+				ThreadID: "thread_123",
+				Message: ThreadMessage{
+					Role: RoleUser,
+					ContentParts: []MessageContentPart{
+						{Type: ContentTypeText, Text: "Describe this"},
+						{Type: ContentTypeImageURL, ImageURL: &ImageURL{URL: "https://example.com/a.png", Detail: ImageDetailLow}},
+					},
+				},
+			},
+			responses:   []int{http.StatusOK},
+			expectPosts: 1,
+		},
+		{
+			name: "empty thread ID",
+			input: CreateMessageInput{
+				Message: ThreadMessage{Role: RoleUser, Content: "Hello"},
+			},
+			expectError: true,
+		},
+		{
+			name: "empty content",
+			input: CreateMessageInput{
+				ThreadID: "thread_123",
+				Message:  ThreadMessage{Role: RoleUser},
+			},
 			expectError: true,
 		},
 		{
@@ -121,8 +395,9 @@ func TestClient_AddMessage(t *testing.T) {
 				ThreadID: "thread_123",
 				Message:  ThreadMessage{Role: "invalid", Content: string([]byte{0x7f})},
 			},
-			serverStatus: http.StatusBadRequest,
-			expectError:  true,
+			responses:   []int{http.StatusBadRequest},
+			expectPosts: 1,
+			expectError: true,
 		},
 	}
 
@@ -130,53 +405,283 @@ func TestClient_AddMessage(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			var callCount int
-			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				require.Equal(t, "/threads/"+tt.input.ThreadID+"/messages", r.URL.Path)
-				require.Equal(t, http.MethodPost, r.Method)
+			var (
+				mu      sync.Mutex
+				posts   int
+				lookups int
+			)
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /threads/{threadID}/messages", func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tt.input.ThreadID, r.PathValue("threadID"))
 				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
 				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
 				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
 
 				var message ThreadMessage
-				err := json.NewDecoder(r.Body).Decode(&message)
-				require.NoError(t, err)
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
 				require.Equal(t, tt.input.Message, message)
 
-				if tt.retryCase && callCount == 0 {
-					callCount++
-					w.WriteHeader(http.StatusBadRequest)
-					json.NewEncoder(w).Encode(map[string]interface{}{
-						"error": "Can't add messages to thread",
+				mu.Lock()
+				status := tt.responses[posts]
+				posts++
+				mu.Unlock()
+
+				w.WriteHeader(status)
+				if status == http.StatusBadRequest {
+					json.NewEncoder(w).Encode(map[string]any{
+						"error": map[string]any{"message": "Can't add messages to thread while a run is active."},
 					})
 					return
 				}
-
-				if len(tt.responses) > 0 {
-					w.WriteHeader(tt.responses[callCount])
-					callCount++
-					return
+				json.NewEncoder(w).Encode(MessageContent{ID: "msg_new", ThreadID: tt.input.ThreadID, Role: tt.input.Message.Role})
+			})
+			mux.HandleFunc("GET /threads/{threadID}/runs", func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "1", r.URL.Query().Get("limit"))
+
+				mu.Lock()
+				list := map[string]any{"object": "list", "data": []Run{}}
+				if lookups < len(tt.runStatuses) {
+					list["data"] = []Run{{ID: "run_1", Status: tt.runStatuses[lookups]}}
 				}
+				lookups++
+				mu.Unlock()
+
+				json.NewEncoder(w).Encode(list)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+			client.retrySleep = withoutRetryDelay(nil, nil)
+
+			message, err := client.AddMessage(context.Background(), tt.input)
+			require.Equal(t, tt.expectPosts, posts)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "msg_new", message.ID)
+			require.Equal(t, tt.input.ThreadID, message.ThreadID)
+		})
+	}
+}
+
+func TestClient_AddMessage_NetworkError(t *testing.T) {
+	t.Parallel()
+
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message ThreadMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		require.Equal(t, "Hello", message.Content)
+
+		posts++
+		json.NewEncoder(w).Encode(MessageContent{ID: "msg_new", ThreadID: "thread_123"})
+	}))
+	defer server.Close()
+
+	// The first attempt fails before reaching the server, like a DNS blip.
+	var attempts int
+	httpClient := server.Client()
+	transport := httpClient.Transport
+	httpClient.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, &net.DNSError{Err: "temporary failure in name resolution", Name: "api.openai.com", IsTemporary: true}
+		}
+		return transport.RoundTrip(r)
+	})
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", httpClient, WithBaseURL(server.URL))
+	client.retrySleep = withoutRetryDelay(nil, nil)
+
+	message, err := client.AddMessage(context.Background(), CreateMessageInput{
+		ThreadID: "thread_123",
+		Message:  ThreadMessage{Role: RoleUser, Content: "Hello"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "msg_new", message.ID)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, 1, posts)
+}
+
+func TestClient_GetMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		messageID      string
+		serverResponse *MessageContent
+		serverStatus   int
+		expectError    bool
+	}{
+		{
+			name:      "successful retrieval",
+			messageID: "msg_123",
+			serverResponse: &MessageContent{
+				ID:        "msg_123",
+				Object:    "thread.message",
+				CreatedAt: 1699009709,
+				ThreadID:  "thread_123",
+				Role:      RoleUser,
+				Content:   []Content{{Type: ContentTypeText, Text: TextValue{Value: "Hello"}}},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:         "not found",
+			messageID:    "msg_nonexistent",
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+		{
+			name:        "empty message ID",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/thread_123/messages/"+tt.messageID, r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
 
 				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
 			}))
 			defer server.Close()
 
 			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
 
-			err := client.AddMessage(context.Background(), tt.input)
+			result, err := client.GetMessage(context.Background(), "thread_123", tt.messageID)
 			if tt.expectError {
 				require.Error(t, err)
 				return
 			}
 
 			require.NoError(t, err)
-			if tt.retryCase {
-				require.Greater(t, callCount, 0, "retry should have occurred")
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_WaitUntilThreadIdle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          []WaitOption
+		runStatuses   []string
+		serverStatus  int
+		expectLookups int
+		expectSleeps  []time.Duration
+		expectError   bool
+	}{
+		{
+			name:          "no runs",
+			expectLookups: 1,
+		},
+		{
+			name:          "latest run terminal",
+			runStatuses:   []string{RunStatusExpired},
+			expectLookups: 1,
+		},
+		{
+			name:          "waits for active run",
+			runStatuses:   []string{RunStatusInProgress, RunStatusCancelling, RunStatusCancelled},
+			expectLookups: 3,
+			expectSleeps:  []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:          "poll options",
+			opts:          []WaitOption{WithPollInterval(100 * time.Millisecond), WithPollBackoff(2)},
+			runStatuses:   []string{RunStatusQueued, RunStatusInProgress, RunStatusInProgress, RunStatusCompleted},
+			expectLookups: 4,
+			expectSleeps:  []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+		},
+		{
+			name:          "server error",
+			serverStatus:  http.StatusNotFound,
+			expectLookups: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var lookups int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/thread_123/runs", r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				lookups++
+				if tt.serverStatus != 0 {
+					w.WriteHeader(tt.serverStatus)
+					return
+				}
+
+				list := map[string]any{"object": "list", "data": []Run{}}
+				if lookups <= len(tt.runStatuses) {
+					list["data"] = []Run{{ID: "run_1", Status: tt.runStatuses[lookups-1]}}
+				}
+				json.NewEncoder(w).Encode(list)
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			var (
+				mu     sync.Mutex
+				sleeps []time.Duration
+			)
+			opts := append([]WaitOption{withoutSleep(&mu, &sleeps)}, tt.opts...)
+
+			err := client.WaitUntilThreadIdle(context.Background(), "thread_123", opts...)
+			require.Equal(t, tt.expectLookups, lookups)
+			require.Equal(t, tt.expectSleeps, sleeps)
+			if tt.expectError {
+				require.Error(t, err)
+				return
 			}
+
+			require.NoError(t, err)
 		})
 	}
+
+	t.Run("context cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]any{
+				"data": []Run{{ID: "run_1", Status: RunStatusRequiresAction}},
+			})
+		}))
+		defer server.Close()
+
+		logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+		client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := client.WaitUntilThreadIdle(ctx, "thread_123")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
 }
 
 func TestClient_WaitForRun(t *testing.T) {
@@ -188,6 +693,8 @@ func TestClient_WaitForRun(t *testing.T) {
 		runID       string
 		responses   []Run
 		expectError bool
+		expectErrIs error
+		errContains string
 	}{
 		{
 			name:     "successful completion",
@@ -220,6 +727,28 @@ func TestClient_WaitForRun(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name:     "pending then completes",
+			threadID: "thread_123",
+			runID:    "run_456",
+			responses: []Run{
+				{Status: RunStatusPending},
+				{Status: RunStatusInProgress},
+				{Status: RunStatusCompleted},
+			},
+		},
+		{
+			name:     "cancelling then cancelled",
+			threadID: "thread_123",
+			runID:    "run_456",
+			responses: []Run{
+				{Status: RunStatusInProgress},
+				{Status: RunStatusCancelling},
+				{Status: RunStatusCancelled},
+			},
+			expectError: true,
+			errContains: "run ended with status: cancelled",
+		},
 		{
 			name:     "requires action then completes",
 			threadID: "thread_123",
@@ -240,6 +769,45 @@ func TestClient_WaitForRun(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name:     "expired with deadline",
+			threadID: "thread_123",
+			runID:    "run_456",
+			responses: []Run{
+				{Status: RunStatusExpired, ExpiresAt: 1699009709},
+			},
+			expectError: true,
+			errContains: "run expired at 2023-11-03T11:08:29Z",
+		},
+		{
+			name:     "failed by content filter",
+			threadID: "thread_123",
+			runID:    "run_456",
+			responses: []Run{
+				{Status: RunStatusFailed, LastError: &RunError{Code: ContentFilterReason, Message: "Blocked"}},
+			},
+			expectError: true,
+			expectErrIs: ErrContentFiltered,
+		},
+		{
+			name:     "incomplete due to content filter",
+			threadID: "thread_123",
+			runID:    "run_456",
+			responses: []Run{
+				{Status: RunStatusIncomplete, IncompleteDetails: &IncompleteDetails{Reason: ContentFilterReason}},
+			},
+			expectError: true,
+			expectErrIs: ErrContentFiltered,
+		},
+		{
+			name:     "incomplete due to token limit",
+			threadID: "thread_123",
+			runID:    "run_456",
+			responses: []Run{
+				{Status: RunStatusIncomplete, IncompleteDetails: &IncompleteDetails{Reason: "max_completion_tokens"}},
+			},
+			expectError: true,
+		},
 		{
 			name:     "unknown status",
 			threadID: "thread_123",
@@ -255,19 +823,259 @@ func TestClient_WaitForRun(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			var callCount int
+			var callCount int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/"+tt.threadID+"/runs/"+tt.runID, r.URL.Path)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				w.WriteHeader(http.StatusOK)
+				if callCount < len(tt.responses) {
+					json.NewEncoder(w).Encode(tt.responses[callCount])
+					callCount++
+				} else {
+					// Return last response for any additional calls
+					json.NewEncoder(w).Encode(tt.responses[len(tt.responses)-1])
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			var (
+				mu     sync.Mutex
+				sleeps []time.Duration
+			)
+			err := client.WaitForRun(context.Background(), tt.threadID, tt.runID,
+				WithPollInterval(10*time.Millisecond),
+				withoutSleep(&mu, &sleeps),
+			)
+			require.Len(t, sleeps, len(tt.responses)-1, "must wait once between polls")
+			if tt.expectError {
+				require.Error(t, err)
+				if tt.errContains != "" {
+					require.ErrorContains(t, err, tt.errContains)
+				}
+				if tt.expectErrIs != nil {
+					require.ErrorIs(t, err, tt.expectErrIs)
+				} else {
+					require.NotErrorIs(t, err, ErrContentFiltered)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_WaitForRunWithCallback(t *testing.T) {
+	t.Parallel()
+
+	responses := []Run{
+		{Status: RunStatusQueued},
+		{Status: RunStatusInProgress},
+		{Status: RunStatusInProgress},
+		{Status: RunStatusCompleted},
+	}
+
+	var callCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/threads/thread_123/runs/run_456", r.URL.Path)
+		json.NewEncoder(w).Encode(responses[min(callCount, len(responses)-1)])
+		callCount++
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	var statuses []string
+	err := client.WaitForRunWithCallback(context.Background(), "thread_123", "run_456", func(run *Run) {
+		statuses = append(statuses, run.Status)
+	}, withoutSleep(nil, nil))
+	require.NoError(t, err)
+	require.Equal(t, []string{RunStatusQueued, RunStatusInProgress, RunStatusCompleted}, statuses)
+}
+
+func TestClient_WaitForRun_ConditionalPolling(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu          sync.Mutex
+		polls       int
+		ifNoneMatch []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		polls++
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+
+		switch polls {
+		case 1:
+			w.Header().Set("ETag", `"v1"`)
+			json.NewEncoder(w).Encode(Run{ID: "run_456", Status: RunStatusInProgress})
+		case 2:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", `"v2"`)
+			json.NewEncoder(w).Encode(Run{ID: "run_456", Status: RunStatusCompleted})
+		}
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	var statuses []string
+	err := client.WaitForRunWithCallback(context.Background(), "thread_123", "run_456", func(run *Run) {
+		statuses = append(statuses, run.Status)
+	}, withoutSleep(nil, nil))
+	require.NoError(t, err)
+	require.Equal(t, []string{"", `"v1"`, `"v1"`}, ifNoneMatch)
+	require.Equal(t, []string{RunStatusInProgress, RunStatusCompleted}, statuses)
+}
+
+func TestClient_GetMessages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		threadID       string
+		params         ListParams
+		expectedQuery  string
+		serverResponse *ThreadMessageList
+		serverStatus   int
+		expectError    bool
+	}{
+		{
+			name:     "successful retrieval",
+			threadID: "thread_123",
+			serverResponse: &ThreadMessageList{
+				Object: "list",
+				Data: []MessageContent{
+					{
+						ID:        "msg_123",
+						Object:    "message",
+						CreatedAt: 1699009709,
+						ThreadID:  "thread_123",
+						Role:      RoleUser,
+						Content: []Content{
+							{
+								Type: "text",
+								Text: TextValue{Value: "test message"},
+							},
+						},
+					},
+				},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "paginated",
+			threadID:      "thread_123",
+			params:        ListParams{Limit: 2, Order: OrderAsc, Before: "msg_200"},
+			expectedQuery: "before=msg_200&limit=2&order=asc",
+			serverResponse: &ThreadMessageList{
+				Object:  "list",
+				Data:    []MessageContent{{ID: "msg_1"}, {ID: "msg_2"}},
+				FirstID: "msg_1",
+				LastID:  "msg_2",
+				HasMore: true,
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:         "not found",
+			threadID:     "thread_nonexistent",
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/"+tt.threadID+"/messages", r.URL.Path)
+				require.Equal(t, tt.expectedQuery, r.URL.RawQuery)
+				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.GetMessages(context.Background(), tt.threadID, tt.params)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_DeleteThread(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		threadID       string
+		serverResponse *DeletionStatus
+		serverStatus   int
+		expectError    bool
+	}{
+		{
+			name:           "successful deletion",
+			threadID:       "thread_123",
+			serverResponse: &DeletionStatus{ID: "thread_123", Object: "thread.deleted", Deleted: true},
+			serverStatus:   http.StatusOK,
+		},
+		{
+			name:           "not deleted",
+			threadID:       "thread_123",
+			serverResponse: &DeletionStatus{ID: "thread_123", Object: "thread.deleted"},
+			serverStatus:   http.StatusOK,
+			expectError:    true,
+		},
+		{
+			name:         "not found",
+			threadID:     "thread_123",
+			serverStatus: http.StatusNotFound,
+			expectError:  true,
+		},
+		{
+			name:        "empty thread ID",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				require.Equal(t, "/threads/"+tt.threadID+"/runs/"+tt.runID, r.URL.Path)
+				require.Equal(t, "/threads/thread_123", r.URL.Path)
+				require.Equal(t, http.MethodDelete, r.Method)
 				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
 				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
 
-				w.WriteHeader(http.StatusOK)
-				if callCount < len(tt.responses) {
-					json.NewEncoder(w).Encode(tt.responses[callCount])
-					callCount++
-				} else {
-					// Return last response for any additional calls
-					json.NewEncoder(w).Encode(tt.responses[len(tt.responses)-1])
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
 				}
 			}))
 			defer server.Close()
@@ -275,11 +1083,7 @@ func TestClient_WaitForRun(t *testing.T) {
 			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
 
-			// Use a longer timeout for tests
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			err := client.WaitForRun(ctx, tt.threadID, tt.runID)
+			err := client.DeleteThread(context.Background(), tt.threadID)
 			if tt.expectError {
 				require.Error(t, err)
 				return
@@ -290,44 +1094,25 @@ func TestClient_WaitForRun(t *testing.T) {
 	}
 }
 
-func TestClient_GetMessages(t *testing.T) {
+func TestClient_DeleteMessage(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name           string
-		threadID       string
-		serverResponse *ThreadMessageList
+		serverResponse *DeletionStatus
 		serverStatus   int
 		expectError    bool
 	}{
 		{
-			name:     "successful retrieval",
-			threadID: "thread_123",
-			serverResponse: &ThreadMessageList{
-				Object: "list",
-				Data: []MessageContent{
-					{
-						ID:        "msg_123",
-						Object:    "message",
-						CreatedAt: 1699009709,
-						ThreadID:  "thread_123",
-						Role:      RoleUser,
-						Content: []Content{
-							{
-								Type: "text",
-								Text: TextValue{Value: "test message"},
-							},
-						},
-					},
-				},
-			},
-			serverStatus: http.StatusOK,
+			name:           "successful deletion",
+			serverResponse: &DeletionStatus{ID: "msg_123", Object: "thread.message.deleted", Deleted: true},
+			serverStatus:   http.StatusOK,
 		},
 		{
-			name:         "not found",
-			threadID:     "thread_nonexistent",
-			serverStatus: http.StatusNotFound,
-			expectError:  true,
+			name:           "not deleted",
+			serverResponse: &DeletionStatus{ID: "msg_123", Object: "thread.message.deleted"},
+			serverStatus:   http.StatusOK,
+			expectError:    true,
 		},
 	}
 
@@ -336,7 +1121,8 @@ func TestClient_GetMessages(t *testing.T) {
 			t.Parallel()
 
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				require.Equal(t, "/threads/"+tt.threadID+"/messages", r.URL.Path)
+				require.Equal(t, "/threads/thread_123/messages/msg_123", r.URL.Path)
+				require.Equal(t, http.MethodDelete, r.Method)
 				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
 				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
 
@@ -350,18 +1136,56 @@ func TestClient_GetMessages(t *testing.T) {
 			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
 
-			result, err := client.GetMessages(context.Background(), tt.threadID)
+			err := client.DeleteMessage(context.Background(), "thread_123", "msg_123")
 			if tt.expectError {
 				require.Error(t, err)
 				return
 			}
 
 			require.NoError(t, err)
-			require.Equal(t, tt.serverResponse, result)
 		})
 	}
 }
 
+func TestClient_ClearThread(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	remaining := []string{"msg_1", "msg_2", "msg_3"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /threads/thread_123/messages", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Serve pages of two messages to exercise the paging loop.
+		list := ThreadMessageList{Object: "list"}
+		for i := 0; i < len(remaining) && i < 2; i++ {
+			list.Data = append(list.Data, MessageContent{ID: remaining[i]})
+		}
+		json.NewEncoder(w).Encode(list)
+	})
+	mux.HandleFunc("DELETE /threads/thread_123/messages/{id}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		id := r.PathValue("id")
+		remaining = slices.DeleteFunc(remaining, func(s string) bool { return s == id })
+		json.NewEncoder(w).Encode(DeletionStatus{ID: id, Deleted: true})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	deleted, err := client.ClearThread(context.Background(), "thread_123")
+	require.NoError(t, err)
+	require.Equal(t, 3, deleted)
+	require.Empty(t, remaining)
+}
+
 func TestClient_RunThread(t *testing.T) {
 	t.Parallel()
 
@@ -434,46 +1258,416 @@ func TestClient_RunThread(t *testing.T) {
 	}
 }
 
-func TestClient_SubmitToolOutputs(t *testing.T) {
+func TestClient_RunThreadWithOptions_WaitForIdle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		lookups int
+		posted  bool
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /threads/thread_123/runs", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		require.False(t, posted, "run created before the thread was idle")
+
+		status := RunStatusInProgress
+		if lookups > 0 {
+			status = RunStatusCompleted
+		}
+		lookups++
+		json.NewEncoder(w).Encode(RunList{Object: "list", Data: []Run{{ID: "run_old", Status: status}}})
+	})
+	mux.HandleFunc("POST /threads/thread_123/runs", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"assistant_id":"asst_123"}`, string(body))
+
+		mu.Lock()
+		posted = true
+		mu.Unlock()
+		json.NewEncoder(w).Encode(Run{ID: "run_new", Status: RunStatusQueued})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	run, err := client.RunThreadWithOptions(context.Background(), "thread_123", "asst_123", &RunOptions{WaitForIdle: true})
+	require.NoError(t, err)
+	require.Equal(t, "run_new", run.ID)
+	require.Equal(t, 2, lookups)
+}
+
+func TestClient_RunThread_CancelledDuringRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		posts int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			AssistantID string `json:"assistant_id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		require.Equal(t, "asst_123", input.AssistantID)
+
+		mu.Lock()
+		posts++
+		attempt := posts
+		mu.Unlock()
+
+		// The first retry is immediate and must replay the body; the second
+		// is far off and cancelled while waiting.
+		if attempt == 1 {
+			w.Header().Set("Retry-After", "0")
+		} else {
+			w.Header().Set("Retry-After", "30")
+			cancel()
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	start := time.Now()
+	_, err := client.RunThread(ctx, "thread_123", "asst_123")
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, 2, posts)
+}
+
+func TestClient_RunThreadWithOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        *RunOptions
+		wantBody    string
+		expectError bool
+	}{
+		{
+			name:     "nil options",
+			wantBody: `{"assistant_id":"asst_123"}`,
+		},
+		{
+			name: "keep last messages",
+			opts: &RunOptions{
+				TruncationStrategy: &TruncationStrategy{Type: TruncationLastMessages, LastMessages: 10},
+			},
+			wantBody: `{"assistant_id":"asst_123","truncation_strategy":{"type":"last_messages","last_messages":10}}`,
+		},
+		{
+			name: "auto truncation",
+			opts: &RunOptions{
+				TruncationStrategy: &TruncationStrategy{Type: TruncationAuto},
+			},
+			wantBody: `{"assistant_id":"asst_123","truncation_strategy":{"type":"auto"}}`,
+		},
+		{
+			name: "code interpreter files",
+			opts: &RunOptions{
+				ToolResources: &ToolResources{
+					CodeInterpreter: &CodeInterpreter{FileIDs: []string{"file-1", "file-2"}},
+				},
+			},
+			wantBody: `{"assistant_id":"asst_123","tool_resources":{"code_interpreter":{"file_ids":["file-1","file-2"]}}}`,
+		},
+		{
+			name: "last messages without count",
+			opts: &RunOptions{
+				TruncationStrategy: &TruncationStrategy{Type: TruncationLastMessages},
+			},
+			expectError: true,
+		},
+		{
+			name:     "temperature",
+			opts:     &RunOptions{Temperature: ptr(0.7)},
+			wantBody: `{"assistant_id":"asst_123","temperature":0.7}`,
+		},
+		{
+			name:        "temperature out of range",
+			opts:        &RunOptions{Temperature: ptr(20.0)},
+			expectError: true,
+		},
+		{
+			name: "unknown strategy",
+			opts: &RunOptions{
+				TruncationStrategy: &TruncationStrategy{Type: "first_messages"},
+			},
+			expectError: true,
+		},
+		{
+			name:     "instructions override",
+			opts:     &RunOptions{Instructions: "Answer in French."},
+			wantBody: `{"assistant_id":"asst_123","instructions":"Answer in French."}`,
+		},
+		{
+			name: "additional instructions",
+			opts: &RunOptions{
+				Instructions:           "Answer in French.",
+				AdditionalInstructions: "The user's name is Ada.",
+			},
+			wantBody: `{"assistant_id":"asst_123","instructions":"Answer in French.","additional_instructions":"The user's name is Ada."}`,
+		},
+		{
+			name:     "metadata",
+			opts:     &RunOptions{Metadata: Meta{"trace_id": "abc123"}},
+			wantBody: `{"assistant_id":"asst_123","metadata":{"trace_id":"abc123"}}`,
+		},
+		{
+			name:        "metadata value not a string",
+			opts:        &RunOptions{Metadata: Meta{"attempt": 2}},
+			expectError: true,
+		},
+		{
+			name:        "metadata value too long",
+			opts:        &RunOptions{Metadata: Meta{"trace_id": strings.Repeat("a", 513)}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/thread_123/runs", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.wantBody, string(body))
+
+				json.NewEncoder(w).Encode(Run{ID: "run_123", Status: RunStatusQueued})
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.RunThreadWithOptions(context.Background(), "thread_123", "asst_123", tt.opts)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "run_123", result.ID)
+		})
+	}
+}
+
+func TestClient_CreateThreadAndRun(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name         string
-		threadID     string
-		runID        string
-		outputs      []ToolOutput
+		input        CreateThreadAndRunInput
+		wantBody     string
 		serverStatus int
 		expectError  bool
 	}{
 		{
-			name:     "successful submission",
-			threadID: "thread_123",
-			runID:    "run_123",
+			name: "messages and metadata",
+			input: CreateThreadAndRunInput{
+				AssistantID: "asst_123",
+				Messages:    []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+				Metadata:    Meta{"session": "abc"},
+			},
+			wantBody: `{"assistant_id":"asst_123","thread":{` +
+				`"messages":[{"role":"user","content":"Hello"}],"metadata":{"session":"abc"}}}`,
+			serverStatus: http.StatusOK,
+		},
+		{
+			name: "run options",
+			input: CreateThreadAndRunInput{
+				AssistantID: "asst_123",
+				Messages:    []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+				Options:     &RunOptions{Temperature: ptr(0.5)},
+			},
+			wantBody:     `{"assistant_id":"asst_123","thread":{"messages":[{"role":"user","content":"Hello"}]},"temperature":0.5}`,
+			serverStatus: http.StatusOK,
+		},
+		{
+			name: "thread and run metadata",
+			input: CreateThreadAndRunInput{
+				AssistantID: "asst_123",
+				Messages:    []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+				Metadata:    Meta{"session": "abc"},
+				Options:     &RunOptions{Metadata: Meta{"trace_id": "xyz"}},
+			},
+			wantBody: `{"assistant_id":"asst_123","thread":{` +
+				`"messages":[{"role":"user","content":"Hello"}],"metadata":{"session":"abc"}},"metadata":{"trace_id":"xyz"}}`,
+			serverStatus: http.StatusOK,
+		},
+		{
+			name: "missing assistant ID",
+			input: CreateThreadAndRunInput{
+				Messages: []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid message",
+			input: CreateThreadAndRunInput{
+				AssistantID: "asst_123",
+				Messages:    []ThreadMessage{{Role: RoleUser}},
+			},
+			expectError: true,
+		},
+		{
+			name: "server error",
+			input: CreateThreadAndRunInput{
+				AssistantID: "asst_123",
+				Messages:    []ThreadMessage{{Role: RoleUser, Content: "Hello"}},
+			},
+			wantBody:     `{"assistant_id":"asst_123","thread":{"messages":[{"role":"user","content":"Hello"}]}}`,
+			serverStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/threads/runs", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.wantBody, string(body))
+
+				w.WriteHeader(tt.serverStatus)
+				json.NewEncoder(w).Encode(Run{ID: "run_123", ThreadID: "thread_new", Status: RunStatusQueued})
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.CreateThreadAndRun(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "run_123", result.ID)
+			require.Equal(t, "thread_new", result.ThreadID)
+		})
+	}
+}
+
+func TestClient_SubmitToolOutputs(t *testing.T) {
+	t.Parallel()
+
+	required := &RequiredAction{
+		Type: "submit_tool_outputs",
+		ToolCalls: []ToolCall{
+			{ID: "call_1", Type: ToolTypeFunction},
+			{ID: "call_2", Type: ToolTypeFunction},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		runID          string
+		requiredAction *RequiredAction
+		outputs        []ToolOutput
+		serverStatus   int
+		expectSubmit   bool
+		errContains    string
+		expectError    bool
+	}{
+		{
+			name:           "successful submission",
+			runID:          "run_123",
+			requiredAction: required,
 			outputs: []ToolOutput{
-				{
-					ToolCallID: "call_123",
-					Output:     "function result",
-				},
+				{ToolCallID: "call_1", Output: "function result"},
+				{ToolCallID: "call_2", Output: "another result"},
+			},
+			serverStatus: http.StatusOK,
+			expectSubmit: true,
+		},
+		{
+			name:  "run without known tool calls",
+			runID: "run_123",
+			outputs: []ToolOutput{
+				{ToolCallID: "call_123", Output: "function result"},
 			},
 			serverStatus: http.StatusOK,
+			expectSubmit: true,
+		},
+		{
+			name:           "duplicate tool call ID",
+			runID:          "run_123",
+			requiredAction: required,
+			outputs: []ToolOutput{
+				{ToolCallID: "call_1", Output: "a"},
+				{ToolCallID: "call_1", Output: "b"},
+			},
+			errContains: "duplicate tool output for tool call 'call_1'",
+			expectError: true,
+		},
+		{
+			name:           "missing and extra tool call IDs",
+			runID:          "run_123",
+			requiredAction: required,
+			outputs: []ToolOutput{
+				{ToolCallID: "call_1", Output: "a"},
+				{ToolCallID: "call_9", Output: "b"},
+			},
+			errContains: "missing outputs for call_2; unexpected outputs for call_9",
+			expectError: true,
 		},
 		{
 			name:         "invalid run",
-			threadID:     "thread_123",
 			runID:        "run_invalid",
 			outputs:      []ToolOutput{},
 			serverStatus: http.StatusNotFound,
 			expectError:  true,
 		},
+		{
+			name:         "submission rejected",
+			runID:        "run_123",
+			outputs:      []ToolOutput{{ToolCallID: "call_1", Output: "a"}},
+			serverStatus: http.StatusBadRequest,
+			expectSubmit: true,
+			expectError:  true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				require.Equal(t, fmt.Sprintf("/threads/%s/runs/%s/submit_tool_outputs", tt.threadID, tt.runID), r.URL.Path)
-				require.Equal(t, http.MethodPost, r.Method)
+			var submitted bool
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /threads/thread_123/runs/{runID}", func(w http.ResponseWriter, r *http.Request) {
+				if r.PathValue("runID") != "run_123" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				json.NewEncoder(w).Encode(Run{
+					ID:             "run_123",
+					Status:         RunStatusRequiresAction,
+					RequiredAction: tt.requiredAction,
+				})
+			})
+			mux.HandleFunc("POST /threads/thread_123/runs/{runID}/submit_tool_outputs", func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tt.runID, r.PathValue("runID"))
 				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
 				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
 
@@ -484,16 +1678,22 @@ func TestClient_SubmitToolOutputs(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tt.outputs, input.ToolOutputs)
 
+				submitted = true
 				w.WriteHeader(tt.serverStatus)
-			}))
+			})
+			server := httptest.NewServer(mux)
 			defer server.Close()
 
 			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
 
-			err := client.SubmitToolOutputs(context.Background(), tt.threadID, tt.runID, tt.outputs)
+			err := client.SubmitToolOutputs(context.Background(), "thread_123", tt.runID, tt.outputs)
+			require.Equal(t, tt.expectSubmit, submitted)
 			if tt.expectError {
 				require.Error(t, err)
+				if tt.errContains != "" {
+					require.ErrorContains(t, err, tt.errContains)
+				}
 				return
 			}
 
@@ -526,6 +1726,19 @@ func TestClient_GetRun(t *testing.T) {
 			},
 			serverStatus: http.StatusOK,
 		},
+		{
+			name:     "with usage",
+			threadID: "thread_123",
+			runID:    "run_456",
+			serverResponse: &Run{
+				ID:       "run_456",
+				Object:   "thread.run",
+				ThreadID: "thread_123",
+				Status:   RunStatusCompleted,
+				Usage:    &Usage{PromptTokens: 120, CompletionTokens: 30, TotalTokens: 150},
+			},
+			serverStatus: http.StatusOK,
+		},
 		{
 			name:         "not found",
 			threadID:     "thread_123",
@@ -533,6 +1746,17 @@ func TestClient_GetRun(t *testing.T) {
 			serverStatus: http.StatusNotFound,
 			expectError:  true,
 		},
+		{
+			name:     "server error with a run body",
+			threadID: "thread_123",
+			runID:    "run_123",
+			serverResponse: &Run{
+				ID:     "run_123",
+				Status: RunStatusInProgress,
+			},
+			serverStatus: http.StatusInternalServerError,
+			expectError:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -545,6 +1769,7 @@ func TestClient_GetRun(t *testing.T) {
 				require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
 				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
 
+				w.Header().Set("Retry-After", "0")
 				w.WriteHeader(tt.serverStatus)
 				if tt.serverResponse != nil {
 					json.NewEncoder(w).Encode(tt.serverResponse)
@@ -557,7 +1782,10 @@ func TestClient_GetRun(t *testing.T) {
 
 			result, err := client.GetRun(context.Background(), tt.threadID, tt.runID)
 			if tt.expectError {
-				require.Error(t, err)
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, tt.serverStatus, apiErr.StatusCode)
+				require.Nil(t, result)
 				return
 			}
 
@@ -566,3 +1794,57 @@ func TestClient_GetRun(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetRun_FunctionTools(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"id": "run_123",
+			"object": "thread.run",
+			"thread_id": "thread_123",
+			"assistant_id": "asst_123",
+			"status": "requires_action",
+			"model": "gpt-4o",
+			"tools": [
+				{"type": "file_search"},
+				{
+					"type": "function",
+					"function": {
+						"name": "get_weather",
+						"description": "Get the current weather of a city",
+						"parameters": {
+							"type": "object",
+							"properties": {"city": {"type": "string"}},
+							"required": ["city"]
+						},
+						"strict": false
+					}
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	run, err := client.GetRun(context.Background(), "thread_123", "run_123")
+	require.NoError(t, err)
+	require.Equal(t, []Tool{
+		{Type: ToolTypeFileSearch},
+		{
+			Type: ToolTypeFunction,
+			Function: &FunctionDefinition{
+				Name:        "get_weather",
+				Description: "Get the current weather of a city",
+				Parameters: map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"city": map[string]any{"type": "string"}},
+					"required":   []any{"city"},
+				},
+				Strict: ptr(false),
+			},
+		},
+	}, run.Tools)
+}
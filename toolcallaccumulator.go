@@ -0,0 +1,144 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ToolCallAccumulator assembles the tool calls of a stream from their
+// fragments, whose function arguments must be concatenated before they can be
+// parsed. Feed it the events of CreateChatCompletionStream or of a run stream
+// with AddEvent, or fragments decoded elsewhere with AddDelta, then read the
+// complete calls with ToolCalls. The zero value is ready to use.
+type ToolCallAccumulator struct {
+	calls map[toolCallKey]*ToolCall
+	// steps lists the run steps in the order their first fragment arrived.
+	steps []string
+}
+
+// toolCallKey identifies a call by its index within its run step, as the
+// index of run step fragments restarts at zero in every step. Fragments of
+// chat completions and those added with AddDelta have no step.
+type toolCallKey struct {
+	step  string
+	index int
+}
+
+// AddDelta merges the fragment into the call of the same index.
+func (a *ToolCallAccumulator) AddDelta(delta ToolCallDelta) {
+	a.addDelta("", delta)
+}
+
+func (a *ToolCallAccumulator) addDelta(step string, delta ToolCallDelta) {
+	if a.calls == nil {
+		a.calls = make(map[toolCallKey]*ToolCall)
+	}
+
+	key := toolCallKey{step: step, index: delta.Index}
+	call, ok := a.calls[key]
+	if !ok {
+		if !slices.Contains(a.steps, step) {
+			a.steps = append(a.steps, step)
+		}
+		call = &ToolCall{}
+		a.calls[key] = call
+	}
+
+	if delta.ID != "" {
+		call.ID = delta.ID
+	}
+	if delta.Type != "" {
+		call.Type = delta.Type
+	}
+	if delta.Function != nil {
+		if delta.Function.Name != "" {
+			call.Function.Name = delta.Function.Name
+		}
+		call.Function.Arguments += delta.Function.Arguments
+	}
+	if ci := delta.CodeInterpreter; ci != nil {
+		if call.CodeInterpreter == nil {
+			call.CodeInterpreter = &CodeInterpreterCall{}
+		}
+		call.CodeInterpreter.Input += ci.Input
+		call.CodeInterpreter.Outputs = append(call.CodeInterpreter.Outputs, ci.Outputs...)
+	}
+}
+
+// AddEvent merges the tool call fragments of a chat completion chunk, from
+// its first choice, or of a run step delta, keeping the calls of each run step
+// apart. Other events are ignored.
+func (a *ToolCallAccumulator) AddEvent(event StreamEvent) error {
+	var (
+		step   string
+		deltas []ToolCallDelta
+	)
+	switch event.Event {
+	case StreamEventChatCompletionChunk:
+		var chunk struct {
+			Choices []struct {
+				Index int `json:"index"`
+				Delta struct {
+					ToolCalls []ToolCallDelta `json:"tool_calls"`
+				} `json:"delta"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal(event.Data, &chunk); err != nil {
+			return fmt.Errorf("could not decode chat completion chunk: %w", err)
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Index == 0 {
+				deltas = choice.Delta.ToolCalls
+			}
+		}
+	case StreamEventRunStepDelta:
+		var stepDelta struct {
+			ID    string `json:"id"`
+			Delta struct {
+				StepDetails struct {
+					ToolCalls []ToolCallDelta `json:"tool_calls"`
+				} `json:"step_details"`
+			} `json:"delta"`
+		}
+		if err := json.Unmarshal(event.Data, &stepDelta); err != nil {
+			return fmt.Errorf("could not decode run step delta: %w", err)
+		}
+		step = stepDelta.ID
+		deltas = stepDelta.Delta.StepDetails.ToolCalls
+	default:
+		return nil
+	}
+
+	for _, delta := range deltas {
+		a.addDelta(step, delta)
+	}
+	return nil
+}
+
+// ToolCalls returns the calls assembled so far, in the order of their run
+// step and then of their index.
+func (a *ToolCallAccumulator) ToolCalls() []ToolCall {
+	order := make(map[string]int, len(a.steps))
+	for i, step := range a.steps {
+		order[step] = i
+	}
+
+	keys := make([]toolCallKey, 0, len(a.calls))
+	for key := range a.calls {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].step != keys[j].step {
+			return order[keys[i].step] < order[keys[j].step]
+		}
+		return keys[i].index < keys[j].index
+	})
+
+	calls := make([]ToolCall, 0, len(keys))
+	for _, key := range keys {
+		calls = append(calls, *a.calls[key])
+	}
+	return calls
+}
@@ -0,0 +1,110 @@
+package openai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolCallAccumulator_AddEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		events      []StreamEvent
+		want        []ToolCall
+		expectError bool
+	}{
+		{
+			name: "chat completion chunks",
+			events: []StreamEvent{
+				{Event: StreamEventChatCompletionChunk, Data: []byte(`{"choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[` +
+					`{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`)},
+				{Event: StreamEventChatCompletionChunk, Data: []byte(`{"choices":[{"index":0,"delta":{"tool_calls":[` +
+					`{"index":0,"function":{"arguments":"{\"city\":"}}]}}]}`)},
+				{Event: StreamEventChatCompletionChunk, Data: []byte(`{"choices":[{"index":0,"delta":{"tool_calls":[` +
+					`{"index":1,"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}]}}]}`)},
+				{Event: StreamEventChatCompletionChunk, Data: []byte(`{"choices":[{"index":0,"delta":{"tool_calls":[` +
+					`{"index":0,"function":{"arguments":"\"Paris\"}"}}]}}]}`)},
+				{Event: StreamEventChatCompletionChunk, Data: []byte(`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`)},
+				{Event: StreamEventUsage, Data: []byte(`{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}`)},
+			},
+			want: []ToolCall{
+				{ID: "call_1", Type: ToolTypeFunction, Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+				{ID: "call_2", Type: ToolTypeFunction, Function: FunctionCall{Name: "get_time", Arguments: `{}`}},
+			},
+		},
+		{
+			name: "run step deltas",
+			events: []StreamEvent{
+				{Event: StreamEventRunStepDelta, Data: []byte(`{"id":"step_1","object":"thread.run.step.delta","delta":{"step_details":{"type":"tool_calls","tool_calls":[` +
+					`{"index":0,"id":"call_1","type":"code_interpreter","code_interpreter":{"input":"","outputs":[]}}]}}}`)},
+				{Event: StreamEventRunStepDelta, Data: []byte(`{"id":"step_1","object":"thread.run.step.delta","delta":{"step_details":{"type":"tool_calls","tool_calls":[` +
+					`{"index":0,"type":"code_interpreter","code_interpreter":{"input":"print(1 + 1)"}}]}}}`)},
+				{Event: StreamEventRunStepDelta, Data: []byte(`{"id":"step_1","object":"thread.run.step.delta","delta":{"step_details":{"type":"tool_calls","tool_calls":[` +
+					`{"index":0,"type":"code_interpreter","code_interpreter":{"outputs":[{"index":0,"type":"logs","logs":"2\n"}]}}]}}}`)},
+				{Event: StreamEventMessageDelta, Data: []byte(`{"id":"msg_1","delta":{"content":[]}}`)},
+			},
+			want: []ToolCall{
+				{
+					ID:   "call_1",
+					Type: ToolTypeCodeInterpreter,
+					CodeInterpreter: &CodeInterpreterCall{
+						Input:   "print(1 + 1)",
+						Outputs: []CodeInterpreterOutput{{Type: "logs", Logs: "2\n"}},
+					},
+				},
+			},
+		},
+		{
+			name: "run step deltas of two steps",
+			events: []StreamEvent{
+				{Event: StreamEventRunStepDelta, Data: []byte(`{"id":"step_1","object":"thread.run.step.delta","delta":{"step_details":{"type":"tool_calls","tool_calls":[` +
+					`{"index":0,"id":"call_1","type":"code_interpreter","code_interpreter":{"input":"print(1 + 1)","outputs":[]}}]}}}`)},
+				{Event: StreamEventRunStepDelta, Data: []byte(`{"id":"step_2","object":"thread.run.step.delta","delta":{"step_details":{"type":"tool_calls","tool_calls":[` +
+					`{"index":0,"id":"call_2","type":"function","function":{"name":"get_weather","arguments":""}}]}}}`)},
+				{Event: StreamEventRunStepDelta, Data: []byte(`{"id":"step_2","object":"thread.run.step.delta","delta":{"step_details":{"type":"tool_calls","tool_calls":[` +
+					`{"index":0,"type":"function","function":{"arguments":"{\"city\":\"Paris\"}"}}]}}}`)},
+			},
+			want: []ToolCall{
+				{
+					ID:              "call_1",
+					Type:            ToolTypeCodeInterpreter,
+					CodeInterpreter: &CodeInterpreterCall{Input: "print(1 + 1)"},
+				},
+				{ID: "call_2", Type: ToolTypeFunction, Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+			},
+		},
+		{
+			name: "malformed chunk",
+			events: []StreamEvent{
+				{Event: StreamEventChatCompletionChunk, Data: []byte(`{"choices":`)},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var acc ToolCallAccumulator
+			for _, event := range tt.events {
+				err := acc.AddEvent(event)
+				if tt.expectError {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, acc.ToolCalls())
+		})
+	}
+}
+
+func TestToolCallAccumulator_Empty(t *testing.T) {
+	t.Parallel()
+
+	var acc ToolCallAccumulator
+	require.Empty(t, acc.ToolCalls())
+}
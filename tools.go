@@ -0,0 +1,198 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ToolCallHandler computes the outputs of the tool calls a run requires.
+type ToolCallHandler func(ctx context.Context, calls []ToolCall) ([]ToolOutput, error)
+
+// maxToolOutputSize is the largest tool output, in bytes, the API accepts for
+// a single tool call.
+const maxToolOutputSize = 512 * 1024
+
+// NewToolOutput builds the output of a tool call by encoding result as compact
+// JSON. It fails if result cannot be encoded or if the encoded output is larger
+// than the API accepts.
+func NewToolOutput(callID string, result any) (ToolOutput, error) {
+	if callID == "" {
+		return ToolOutput{}, fmt.Errorf("tool call ID is required")
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return ToolOutput{}, fmt.Errorf("could not marshal tool output: %w", err)
+	}
+
+	if len(b) > maxToolOutputSize {
+		return ToolOutput{}, fmt.Errorf("tool output is %d bytes, exceeding the limit of %d bytes", len(b), maxToolOutputSize)
+	}
+
+	return ToolOutput{
+		ToolCallID: callID,
+		Output:     string(b),
+	}, nil
+}
+
+// handleToolCalls computes the outputs of the tool calls with handler and
+// submits them to the run.
+func (c *Client) handleToolCalls(ctx context.Context, threadID, runID string, calls []ToolCall, handler ToolCallHandler) error {
+	outputs, err := handler(ctx, calls)
+	if err != nil {
+		return fmt.Errorf("tool call handler failed: %w", err)
+	}
+
+	if err := c.submitToolOutputs(ctx, threadID, runID, outputs, calls); err != nil {
+		return fmt.Errorf("could not submit tool outputs: %w", err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the tool calls nested under submit_tool_outputs, like
+// the API does. Actions of another type without tool calls leave it out.
+func (a RequiredAction) MarshalJSON() ([]byte, error) {
+	type requiredAction RequiredAction
+	out := struct {
+		requiredAction
+		SubmitToolOutputs *submitToolOutputsAction `json:"submit_tool_outputs,omitempty"`
+	}{
+		requiredAction: requiredAction(a),
+	}
+	if a.Type == "submit_tool_outputs" || len(a.ToolCalls) > 0 {
+		out.SubmitToolOutputs = &submitToolOutputsAction{ToolCalls: a.ToolCalls}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the tool calls the API nests under
+// submit_tool_outputs into ToolCalls.
+func (a *RequiredAction) UnmarshalJSON(b []byte) error {
+	type requiredAction RequiredAction
+	var in struct {
+		requiredAction
+		SubmitToolOutputs *submitToolOutputsAction `json:"submit_tool_outputs"`
+	}
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	*a = RequiredAction(in.requiredAction)
+	if in.SubmitToolOutputs != nil {
+		a.ToolCalls = in.SubmitToolOutputs.ToolCalls
+	}
+	return nil
+}
+
+type submitToolOutputsAction struct {
+	ToolCalls []ToolCall `json:"tool_calls"`
+}
+
+// SubmitToolOutputsAndWait submits the outputs and waits for the run to
+// finish. When the run requires action again, handler is called with the new
+// tool calls and its outputs are submitted in turn, until the run reaches a
+// terminal status. A run still requiring the calls just answered hasn't taken
+// the outputs in yet and is polled again, so the handler only sees new calls.
+// A nil handler makes further tool calls an error. The last run seen is
+// returned, also along with an error when one is available.
+func (c *Client) SubmitToolOutputsAndWait(
+	ctx context.Context,
+	threadID, runID string,
+	outputs []ToolOutput,
+	handler ToolCallHandler,
+) (_ *Run, err error) {
+	ctx, op := c.startOperation(ctx, "SubmitToolOutputsAndWait")
+	defer op.end(&err)
+
+	cfg := newWaitConfig(nil)
+	cfg.stopOnAction = true
+	cfg.answered = make(map[string]bool)
+
+	// The run is only fetched to check the first outputs, later ones answer
+	// the tool calls of the run just polled.
+	var run *Run
+	for {
+		if run == nil {
+			err = c.SubmitToolOutputs(ctx, threadID, runID, outputs)
+		} else {
+			err = c.submitToolOutputs(ctx, threadID, runID, outputs, run.RequiredAction.ToolCalls)
+		}
+		if err != nil {
+			return run, fmt.Errorf("could not submit tool outputs: %w", err)
+		}
+		for _, out := range outputs {
+			cfg.answered[out.ToolCallID] = true
+		}
+
+		run, err = c.waitForRun(ctx, threadID, runID, cfg)
+		if err != nil {
+			return run, err
+		}
+
+		if run.Status != RunStatusRequiresAction {
+			return run, nil
+		}
+
+		if run.RequiredAction == nil || len(run.RequiredAction.ToolCalls) == 0 {
+			return run, fmt.Errorf("run requires action without tool calls")
+		}
+
+		if handler == nil {
+			return run, fmt.Errorf("run requires %d more tool calls but no handler was given", len(run.RequiredAction.ToolCalls))
+		}
+
+		outputs, err = handler(ctx, run.RequiredAction.ToolCalls)
+		if err != nil {
+			return run, fmt.Errorf("tool call handler failed: %w", err)
+		}
+	}
+}
+
+// validateToolOutputs checks that every output has a distinct tool call ID
+// and, when the required tool calls are known, that the outputs answer exactly
+// those calls.
+func validateToolOutputs(outputs []ToolOutput, required []ToolCall) error {
+	submitted := make(map[string]bool, len(outputs))
+	for _, out := range outputs {
+		if out.ToolCallID == "" {
+			return fmt.Errorf("tool output without a tool call ID")
+		}
+		if submitted[out.ToolCallID] {
+			return fmt.Errorf("duplicate tool output for tool call '%s'", out.ToolCallID)
+		}
+		submitted[out.ToolCallID] = true
+	}
+
+	if len(required) == 0 {
+		return nil
+	}
+
+	var missing, extra []string
+	expected := make(map[string]bool, len(required))
+	for _, call := range required {
+		expected[call.ID] = true
+		if !submitted[call.ID] {
+			missing = append(missing, call.ID)
+		}
+	}
+	for _, out := range outputs {
+		if !expected[out.ToolCallID] {
+			extra = append(extra, out.ToolCallID)
+		}
+	}
+
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing outputs for "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		problems = append(problems, "unexpected outputs for "+strings.Join(extra, ", "))
+	}
+	return fmt.Errorf("tool outputs do not match the run's required tool calls: %s", strings.Join(problems, "; "))
+}
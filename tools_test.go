@@ -0,0 +1,442 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToolOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		callID      string
+		result      any
+		want        ToolOutput
+		expectError bool
+	}{
+		{
+			name:   "struct result",
+			callID: "call_123",
+			result: struct {
+				City string  `json:"city"`
+				Temp float64 `json:"temp"`
+			}{City: "Lisbon", Temp: 21.5},
+			want: ToolOutput{ToolCallID: "call_123", Output: `{"city":"Lisbon","temp":21.5}`},
+		},
+		{
+			name:   "string result is JSON encoded",
+			callID: "call_123",
+			result: "done",
+			want:   ToolOutput{ToolCallID: "call_123", Output: `"done"`},
+		},
+		{
+			name:   "raw JSON is compacted",
+			callID: "call_123",
+			result: json.RawMessage(`{ "ok" : true }`),
+			want:   ToolOutput{ToolCallID: "call_123", Output: `{"ok":true}`},
+		},
+		{
+			name:        "missing call ID",
+			result:      "done",
+			expectError: true,
+		},
+		{
+			name:        "unsupported value",
+			callID:      "call_123",
+			result:      make(chan int),
+			expectError: true,
+		},
+		{
+			name:        "output too large",
+			callID:      "call_123",
+			result:      strings.Repeat("a", maxToolOutputSize),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewToolOutput(tt.callID, tt.result)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_SubmitToolOutputsAndWait(t *testing.T) {
+	t.Parallel()
+
+	secondRound := &RequiredAction{
+		Type:      "submit_tool_outputs",
+		ToolCalls: []ToolCall{{ID: "call_2", Type: ToolTypeFunction, Function: FunctionCall{Name: "get_time"}}},
+	}
+
+	tests := []struct {
+		name        string
+		runs        []Run // Run returned after each submission
+		handler     ToolCallHandler
+		wantStatus  string
+		wantSubmits [][]ToolOutput
+		wantGets    int // The run is fetched to check the first outputs only
+		expectError bool
+	}{
+		{
+			name:        "completes after submission",
+			runs:        []Run{{Status: RunStatusCompleted}},
+			wantStatus:  RunStatusCompleted,
+			wantSubmits: [][]ToolOutput{{{ToolCallID: "call_1", Output: "sunny"}}},
+			wantGets:    2,
+		},
+		{
+			name: "handles another round of tool calls",
+			runs: []Run{
+				{Status: RunStatusRequiresAction, RequiredAction: secondRound},
+				{Status: RunStatusCompleted},
+			},
+			handler: func(_ context.Context, calls []ToolCall) ([]ToolOutput, error) {
+				return []ToolOutput{{ToolCallID: calls[0].ID, Output: "noon"}}, nil
+			},
+			wantStatus: RunStatusCompleted,
+			wantSubmits: [][]ToolOutput{
+				{{ToolCallID: "call_1", Output: "sunny"}},
+				{{ToolCallID: "call_2", Output: "noon"}},
+			},
+			wantGets: 3,
+		},
+		{
+			name:        "more tool calls without handler",
+			runs:        []Run{{Status: RunStatusRequiresAction, RequiredAction: secondRound}},
+			wantStatus:  RunStatusRequiresAction,
+			wantSubmits: [][]ToolOutput{{{ToolCallID: "call_1", Output: "sunny"}}},
+			wantGets:    2,
+			expectError: true,
+		},
+		{
+			name: "handler error",
+			runs: []Run{{Status: RunStatusRequiresAction, RequiredAction: secondRound}},
+			handler: func(context.Context, []ToolCall) ([]ToolOutput, error) {
+				return nil, errors.New("tool unavailable")
+			},
+			wantStatus:  RunStatusRequiresAction,
+			wantSubmits: [][]ToolOutput{{{ToolCallID: "call_1", Output: "sunny"}}},
+			wantGets:    2,
+			expectError: true,
+		},
+		{
+			name:        "run fails",
+			runs:        []Run{{Status: RunStatusFailed, LastError: &RunError{Code: "server_error", Message: "boom"}}},
+			wantStatus:  RunStatusFailed,
+			wantSubmits: [][]ToolOutput{{{ToolCallID: "call_1", Output: "sunny"}}},
+			wantGets:    2,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu      sync.Mutex
+				submits [][]ToolOutput
+				gets    int
+			)
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /threads/thread_123/runs/run_123/submit_tool_outputs", func(w http.ResponseWriter, r *http.Request) {
+				var input struct {
+					ToolOutputs []ToolOutput `json:"tool_outputs"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+				mu.Lock()
+				submits = append(submits, input.ToolOutputs)
+				mu.Unlock()
+
+				json.NewEncoder(w).Encode(Run{ID: "run_123", Status: RunStatusQueued})
+			})
+			mux.HandleFunc("GET /threads/thread_123/runs/run_123", func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				gets++
+				run := Run{
+					Status: RunStatusRequiresAction,
+					RequiredAction: &RequiredAction{
+						Type:      "submit_tool_outputs",
+						ToolCalls: []ToolCall{{ID: "call_1", Type: ToolTypeFunction}},
+					},
+				}
+				if len(submits) > 0 {
+					run = tt.runs[len(submits)-1]
+				}
+				mu.Unlock()
+
+				run.ID = "run_123"
+				json.NewEncoder(w).Encode(run)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			run, err := client.SubmitToolOutputsAndWait(
+				context.Background(),
+				"thread_123",
+				"run_123",
+				[]ToolOutput{{ToolCallID: "call_1", Output: "sunny"}},
+				tt.handler,
+			)
+			require.Equal(t, tt.wantSubmits, submits)
+			require.Equal(t, tt.wantGets, gets)
+			require.NotNil(t, run)
+			require.Equal(t, tt.wantStatus, run.Status)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_SubmitToolOutputsAndWait_StaleRequiredAction(t *testing.T) {
+	t.Parallel()
+
+	requires := func(callID string) Run {
+		return Run{
+			ID:     "run_123",
+			Status: RunStatusRequiresAction,
+			RequiredAction: &RequiredAction{
+				Type:      "submit_tool_outputs",
+				ToolCalls: []ToolCall{{ID: callID, Type: ToolTypeFunction}},
+			},
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		submits [][]ToolOutput
+		polls   int // Polls since the last submission
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /threads/thread_123/runs/run_123/submit_tool_outputs", func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			ToolOutputs []ToolOutput `json:"tool_outputs"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		mu.Lock()
+		submits = append(submits, input.ToolOutputs)
+		polls = 0
+		mu.Unlock()
+
+		json.NewEncoder(w).Encode(Run{ID: "run_123", Status: RunStatusQueued})
+	})
+	mux.HandleFunc("GET /threads/thread_123/runs/run_123", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		polls++
+		run := requires("call_1")
+		switch {
+		case len(submits) == 1 && polls > 1:
+			// The first poll after the submission still shows call_1.
+			run = requires("call_2")
+		case len(submits) == 2:
+			run = Run{ID: "run_123", Status: RunStatusCompleted}
+		}
+		json.NewEncoder(w).Encode(run)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	var handled [][]ToolCall
+	run, err := client.SubmitToolOutputsAndWait(
+		context.Background(),
+		"thread_123",
+		"run_123",
+		[]ToolOutput{{ToolCallID: "call_1", Output: "sunny"}},
+		func(_ context.Context, calls []ToolCall) ([]ToolOutput, error) {
+			handled = append(handled, calls)
+			return []ToolOutput{{ToolCallID: calls[0].ID, Output: "noon"}}, nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, RunStatusCompleted, run.Status)
+	require.Len(t, handled, 1)
+	require.Equal(t, "call_2", handled[0][0].ID)
+	require.Equal(t, [][]ToolOutput{
+		{{ToolCallID: "call_1", Output: "sunny"}},
+		{{ToolCallID: "call_2", Output: "noon"}},
+	}, submits)
+}
+
+func TestRequiredAction_JSON(t *testing.T) {
+	t.Parallel()
+
+	// Trimmed from a real run retrieved while it required action
+	payload := `{
+		"id": "run_abc123",
+		"object": "thread.run",
+		"thread_id": "thread_abc123",
+		"assistant_id": "asst_abc123",
+		"status": "requires_action",
+		"required_action": {
+			"type": "submit_tool_outputs",
+			"submit_tool_outputs": {
+				"tool_calls": [
+					{
+						"id": "call_abc123",
+						"type": "function",
+						"function": {
+							"name": "get_current_weather",
+							"arguments": "{\"location\":\"San Francisco\"}"
+						}
+					}
+				]
+			}
+		}
+	}`
+
+	var run Run
+	require.NoError(t, json.Unmarshal([]byte(payload), &run))
+	require.Equal(t, &RequiredAction{
+		Type: "submit_tool_outputs",
+		ToolCalls: []ToolCall{{
+			ID:   "call_abc123",
+			Type: ToolTypeFunction,
+			Function: FunctionCall{
+				Name:      "get_current_weather",
+				Arguments: `{"location":"San Francisco"}`,
+			},
+		}},
+	}, run.RequiredAction)
+
+	b, err := json.Marshal(run.RequiredAction)
+	require.NoError(t, err)
+
+	var roundTrip RequiredAction
+	require.NoError(t, json.Unmarshal(b, &roundTrip))
+	require.Equal(t, *run.RequiredAction, roundTrip)
+	require.Contains(t, string(b), `"submit_tool_outputs":{"tool_calls":[`)
+}
+
+func TestRequiredAction_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		action RequiredAction
+		want   string
+	}{
+		{
+			name:   "submit tool outputs",
+			action: RequiredAction{Type: "submit_tool_outputs", ToolCalls: []ToolCall{{ID: "call_1", Type: ToolTypeFunction}}},
+			want:   `{"type":"submit_tool_outputs","submit_tool_outputs":{"tool_calls":[{"id":"call_1","type":"function","function":{"name":"","arguments":""}}]}}`,
+		},
+		{
+			name:   "zero value",
+			action: RequiredAction{},
+			want:   `{"type":""}`,
+		},
+		{
+			name:   "another type",
+			action: RequiredAction{Type: "other_action"},
+			want:   `{"type":"other_action"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(tt.action)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(b))
+		})
+	}
+}
+
+func TestToolCall_FunctionArguments(t *testing.T) {
+	t.Parallel()
+
+	// A requires_action run as returned by the API, with two parallel calls
+	payload := `{
+		"id": "run_qJL1kI9xxWlfE0z1yfL0fGg9",
+		"object": "thread.run",
+		"created_at": 1699075592,
+		"assistant_id": "asst_nGl00s4xa9zmVY6Fvuvz9wwQ",
+		"thread_id": "thread_EdR8UvCDJ5UkSRmW3KNNbDuY",
+		"status": "requires_action",
+		"required_action": {
+			"type": "submit_tool_outputs",
+			"submit_tool_outputs": {
+				"tool_calls": [
+					{
+						"id": "call_FthC9qRpsL5kBpwwyw6c7j4k",
+						"type": "function",
+						"function": {
+							"name": "get_current_temperature",
+							"arguments": "{\"location\": \"San Francisco, CA\", \"unit\": \"Fahrenheit\"}"
+						}
+					},
+					{
+						"id": "call_RpEDoB8O0FTL9JoKTuCVFOyR",
+						"type": "function",
+						"function": {
+							"name": "get_rain_probability",
+							"arguments": "{\"location\": \"San Francisco, CA\"}"
+						}
+					}
+				]
+			}
+		},
+		"model": "gpt-4o",
+		"tools": [
+			{"type": "function", "function": {"name": "get_current_temperature"}},
+			{"type": "function", "function": {"name": "get_rain_probability"}}
+		]
+	}`
+
+	var run Run
+	require.NoError(t, json.Unmarshal([]byte(payload), &run))
+	require.NotNil(t, run.RequiredAction)
+	require.Len(t, run.RequiredAction.ToolCalls, 2)
+
+	call := run.RequiredAction.ToolCalls[0]
+	require.Equal(t, "call_FthC9qRpsL5kBpwwyw6c7j4k", call.ID)
+	require.Equal(t, ToolTypeFunction, call.Type)
+	require.Equal(t, "get_current_temperature", call.Function.Name)
+
+	var args struct {
+		Location string `json:"location"`
+		Unit     string `json:"unit"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(call.Function.Arguments), &args))
+	require.Equal(t, "San Francisco, CA", args.Location)
+	require.Equal(t, "Fahrenheit", args.Unit)
+
+	require.Equal(t, "get_rain_probability", run.RequiredAction.ToolCalls[1].Function.Name)
+	require.JSONEq(t, `{"location": "San Francisco, CA"}`, run.RequiredAction.ToolCalls[1].Function.Arguments)
+}
@@ -1,21 +1,22 @@
 package openai
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"path/filepath"
-	"strings"
 	"time"
-
-	"github.com/wiselead-ai/httpclient"
 )
 
-func (c *Client) CreateVectorStore(ctx context.Context, in *CreateVectorStoreInput) (*VectorStore, error) {
+// CreateVectorStore creates a vector store holding the uploaded files, after
+// checking that file_search supports each of them. Every file that fails the
+// check is reported in the returned error, joined with errors.Join.
+func (c *Client) CreateVectorStore(ctx context.Context, in *CreateVectorStoreInput) (_ *VectorStore, err error) {
+	ctx, op := c.startOperation(ctx, "CreateVectorStore")
+	defer op.end(&err)
+
 	if in == nil {
 		return nil, fmt.Errorf("input cannot be nil")
 	}
@@ -28,93 +29,92 @@ func (c *Client) CreateVectorStore(ctx context.Context, in *CreateVectorStoreInp
 		return nil, fmt.Errorf("fileIDs is required")
 	}
 
-	// Validate file types before creating vector store
+	// Validate file types before creating vector store, reporting every
+	// invalid file at once rather than one per call
+	var errs []error
 	for _, fileID := range in.FileIDs {
 		fileInfo, err := c.GetFileMetadata(ctx, fileID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get file metadata for %s: %w", fileID, err)
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			errs = append(errs, fmt.Errorf("failed to get file metadata for %s: %w", fileID, err))
+			continue
 		}
 
-		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileInfo.Filename), "."))
-		if !supportedFileTypes[ext] {
-			return nil, fmt.Errorf(
-				"file %s has unsupported extension '.%s'. Supported types: .pdf, .txt, .json, .md",
-				fileInfo.Filename, ext,
-			)
+		ext := filepath.Ext(fileInfo.Filename)
+		if !IsSupportedFileType(ext) {
+			errs = append(errs, fmt.Errorf("file %s has unsupported extension '%s'", fileInfo.Filename, ext))
 		}
 	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
 
 	c.logger.Info("Creating vector store",
 		slog.String("name", in.Name),
 		slog.Any("fileIDs", in.FileIDs))
 
-	body, err := json.Marshal(in)
+	req, err := c.newRequest(ctx, http.MethodPost, "/vector_stores", in)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/vector_stores", bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	var out VectorStore
+	if err := c.sendRequest(req, &out); err != nil {
+		return nil, err
 	}
+	return &out, nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
+// GetVectorStore retrieves the vector store, including its status and the
+// counts of its files by status.
+func (c *Client) GetVectorStore(ctx context.Context, vectorStoreID string) (_ *VectorStore, err error) {
+	ctx, op := c.startOperation(ctx, "GetVectorStore")
+	defer op.end(&err)
 
-	resp, err := httpclient.DoWithRetry(c.httpClient, req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+	if vectorStoreID == "" {
+		return nil, fmt.Errorf("vector store ID is required")
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create vector store, status: '%s', body: '%s'", resp.Status, b)
+	req, err := c.newRequest(ctx, http.MethodGet, "/vector_stores/"+vectorStoreID, nil)
+	if err != nil {
+		return nil, err
 	}
 
 	var out VectorStore
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := c.sendRequest(req, &out); err != nil {
+		return nil, err
 	}
 	return &out, nil
 }
 
-func (c *Client) WaitForVectorStoreCompletion(ctx context.Context, vectorStoreID string, timeout, maxDelay time.Duration) error {
+// WaitForVectorStoreCompletion polls the vector store with exponential backoff
+// until it is completed, it failed, timeout elapsed or ctx is done.
+func (c *Client) WaitForVectorStoreCompletion(ctx context.Context, vectorStoreID string, timeout, maxDelay time.Duration) (err error) {
+	ctx, op := c.startOperation(ctx, "WaitForVectorStoreCompletion")
+	defer op.end(&err)
+
 	startTime := time.Now()
 	delay := 1 * time.Second // initial delay for exponential backoff
 
 	for {
-		c.logger.Info("Checking vector store status", slog.String("vectorStoreID", vectorStoreID))
-
-		req, err := http.NewRequest(http.MethodGet, c.baseURL+"/vector_stores/"+vectorStoreID, nil)
-		if err != nil {
-			return fmt.Errorf("failed to create HTTP request: %w", err)
-		}
-
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
-		req.Header.Set("OpenAI-Beta", "assistants=v2")
+		c.logger.Debug("Checking vector store status", slog.String("vectorStoreID", vectorStoreID))
 
-		resp, err := httpclient.DoWithRetry(c.httpClient, req)
+		response, err := c.GetVectorStore(ctx, vectorStoreID)
 		if err != nil {
-			return fmt.Errorf("failed to send HTTP request: %w", err)
-		}
-		defer resp.Body.Close()
-
-		var response VectorStore
-		if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+			return err
 		}
 
-		c.logger.Info("Vector store response", slog.Any("response", response))
+		c.logger.Debug("Vector store response", slog.Any("response", response))
 
 		if response.Status == "completed" {
-			c.logger.Info("Vector store creation completed successfully")
+			c.logger.Info("Vector store creation completed successfully", slog.String("vectorStoreID", vectorStoreID))
 			return nil
 		}
 
 		if response.Status == "failed" {
+			c.logger.Info("Vector store creation failed", slog.String("vectorStoreID", vectorStoreID))
 			return fmt.Errorf("vector store creation failed")
 		}
 
@@ -125,35 +125,210 @@ func (c *Client) WaitForVectorStoreCompletion(ctx context.Context, vectorStoreID
 		if delay < maxDelay {
 			delay *= 2 // Double the delay for the next attempt
 		}
-		c.logger.Info("Waiting for delay before retrying", slog.Any("delay", delay))
-		time.Sleep(delay)
+		c.logger.Debug("Waiting for delay before retrying", slog.Any("delay", delay))
+		if err := sleepContext(ctx, delay); err != nil {
+			return fmt.Errorf("stopped waiting for vector store completion: %w", err)
+		}
 	}
 }
 
-// Add new helper method to get file metadata
-func (c *Client) GetFileMetadata(ctx context.Context, fileID string) (*FileDetails, error) {
-	req, err := http.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("%s/files/%s", c.baseURL, fileID),
-		nil,
-	)
+// ListVectorStores retrieves a page of the vector stores. Use params.After
+// with the LastID of the previous page to fetch the next one.
+func (c *Client) ListVectorStores(ctx context.Context, params ListParams) (_ *VectorStoreList, err error) {
+	ctx, op := c.startOperation(ctx, "ListVectorStores")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/vector_stores"+params.query(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out VectorStoreList
+	if err := c.sendRequest(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteVectorStore deletes the vector store. The files it holds are not
+// deleted.
+func (c *Client) DeleteVectorStore(ctx context.Context, vectorStoreID string) (err error) {
+	ctx, op := c.startOperation(ctx, "DeleteVectorStore")
+	defer op.end(&err)
+
+	if vectorStoreID == "" {
+		return fmt.Errorf("vector store ID is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodDelete, "/vector_stores/"+vectorStoreID, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return err
+	}
+
+	var status DeletionStatus
+	if err := c.sendRequest(req, &status); err != nil {
+		return err
+	}
+
+	if !status.Deleted {
+		return fmt.Errorf("vector store '%s' was not deleted", vectorStoreID)
+	}
+	return nil
+}
+
+// CreateVectorStoreFile adds an uploaded file to the vector store. The file is
+// indexed in the background: poll ListVectorStoreFiles until its status is
+// completed, or failed with the reason in LastError.
+func (c *Client) CreateVectorStoreFile(ctx context.Context, vectorStoreID, fileID string) (_ *VectorStoreFile, err error) {
+	ctx, op := c.startOperation(ctx, "CreateVectorStoreFile")
+	defer op.end(&err)
+
+	if vectorStoreID == "" {
+		return nil, fmt.Errorf("vector store ID is required")
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if fileID == "" {
+		return nil, fmt.Errorf("file ID is required")
+	}
 
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, http.MethodPost, "/vector_stores/"+vectorStoreID+"/files", struct {
+		FileID string `json:"file_id"`
+	}{
+		FileID: fileID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var fileInfo FileDetails
-	if err := json.NewDecoder(resp.Body).Decode(&fileInfo); err != nil {
-		return nil, fmt.Errorf("failed to decode file metadata: %w", err)
+	var out VectorStoreFile
+	if err := c.sendRequest(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListVectorStoreFiles retrieves a page of the files of the vector store. Use
+// params.After with the LastID of the previous page to fetch the next one.
+func (c *Client) ListVectorStoreFiles(ctx context.Context, vectorStoreID string, params ListParams) (_ *VectorStoreFileList, err error) {
+	ctx, op := c.startOperation(ctx, "ListVectorStoreFiles")
+	defer op.end(&err)
+
+	if vectorStoreID == "" {
+		return nil, fmt.Errorf("vector store ID is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/vector_stores/%s/files%s", vectorStoreID, params.query()), nil)
+	if err != nil {
+		return nil, err
 	}
 
+	var out VectorStoreFileList
+	if err := c.sendRequest(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateVectorStoreFileBatch adds uploaded files to the vector store in a
+// single batch, which is indexed in the background. Use WaitForFileBatch to
+// wait for it.
+func (c *Client) CreateVectorStoreFileBatch(ctx context.Context, vectorStoreID string, fileIDs []string) (_ *VectorStoreFileBatch, err error) {
+	ctx, op := c.startOperation(ctx, "CreateVectorStoreFileBatch")
+	defer op.end(&err)
+
+	if vectorStoreID == "" {
+		return nil, fmt.Errorf("vector store ID is required")
+	}
+
+	if len(fileIDs) == 0 {
+		return nil, fmt.Errorf("fileIDs is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/vector_stores/"+vectorStoreID+"/file_batches", struct {
+		FileIDs []string `json:"file_ids"`
+	}{
+		FileIDs: fileIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out VectorStoreFileBatch
+	if err := c.sendRequest(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetVectorStoreFileBatch retrieves a file batch of the vector store.
+func (c *Client) GetVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string) (_ *VectorStoreFileBatch, err error) {
+	ctx, op := c.startOperation(ctx, "GetVectorStoreFileBatch")
+	defer op.end(&err)
+
+	if vectorStoreID == "" {
+		return nil, fmt.Errorf("vector store ID is required")
+	}
+
+	if batchID == "" {
+		return nil, fmt.Errorf("batch ID is required")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/vector_stores/%s/file_batches/%s", vectorStoreID, batchID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out VectorStoreFileBatch
+	if err := c.sendRequest(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WaitForFileBatch polls the file batch until it is done and returns it. The
+// batch is completed once every file was processed, even if some of them
+// failed, as counted by its FileCounts. A failed or cancelled batch is
+// returned along with an error. Polling is tuned with the same options as
+// WaitForRun.
+func (c *Client) WaitForFileBatch(ctx context.Context, vectorStoreID, batchID string, opts ...WaitOption) (_ *VectorStoreFileBatch, err error) {
+	ctx, op := c.startOperation(ctx, "WaitForFileBatch")
+	defer op.end(&err)
+
+	cfg := newWaitConfig(opts)
+	interval := cfg.interval
+	for {
+		batch, err := c.GetVectorStoreFileBatch(ctx, vectorStoreID, batchID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file batch: %w", err)
+		}
+
+		switch batch.Status {
+		case VectorStoreFileStatusCompleted:
+			return batch, nil
+		case VectorStoreFileStatusFailed, VectorStoreFileStatusCancelled:
+			return batch, fmt.Errorf("file batch %s %s", batchID, batch.Status)
+		}
+
+		if err := cfg.sleep(ctx, interval); err != nil {
+			return batch, err
+		}
+		interval = cfg.nextInterval(interval)
+	}
+}
+
+// Add new helper method to get file metadata
+func (c *Client) GetFileMetadata(ctx context.Context, fileID string) (_ *FileDetails, err error) {
+	ctx, op := c.startOperation(ctx, "GetFileMetadata")
+	defer op.end(&err)
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/files/"+fileID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileInfo FileDetails
+	if err := c.sendRequest(req, &fileInfo); err != nil {
+		return nil, fmt.Errorf("failed to get file metadata: %w", err)
+	}
 	return &fileInfo, nil
 }
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -47,6 +48,37 @@ func TestClient_CreateVectorStore(t *testing.T) {
 			},
 			serverStatus: http.StatusOK,
 		},
+		{
+			name: "docx file",
+			input: &CreateVectorStoreInput{
+				Name:    "Docs Store",
+				FileIDs: []string{"file-123"},
+			},
+			fileMetadata: &FileDetails{
+				ID:       "file-123",
+				Filename: "Report.DOCX",
+				Purpose:  "assistants",
+			},
+			serverResponse: &VectorStore{
+				ID:     "vec_456",
+				Object: "vector_store",
+				Name:   "Docs Store",
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name: "unsupported file type",
+			input: &CreateVectorStoreInput{
+				Name:    "Sheets Store",
+				FileIDs: []string{"file-123"},
+			},
+			fileMetadata: &FileDetails{
+				ID:       "file-123",
+				Filename: "data.xlsx",
+				Purpose:  "assistants",
+			},
+			expectedError: true,
+		},
 		{
 			name: "invalid request",
 			input: &CreateVectorStoreInput{
@@ -98,3 +130,619 @@ func TestClient_CreateVectorStore(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_CreateVectorStore_ReportsEveryInvalidFile(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"file-1": "notes.txt",
+		"file-2": "data.xlsx",
+		"file-3": "slides.key",
+	}
+
+	var created bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/vector_stores" {
+			created = true
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/files/")
+		filename, ok := files[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(FileDetails{ID: id, Filename: filename})
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	_, err := client.CreateVectorStore(context.Background(), &CreateVectorStoreInput{
+		Name:    "Mixed Store",
+		FileIDs: []string{"file-1", "file-2", "file-3", "file-4"},
+	})
+	require.Error(t, err)
+	require.False(t, created)
+
+	require.ErrorContains(t, err, "file data.xlsx has unsupported extension '.xlsx'")
+	require.ErrorContains(t, err, "file slides.key has unsupported extension '.key'")
+	require.ErrorContains(t, err, "failed to get file metadata for file-4")
+	require.NotContains(t, err.Error(), "notes.txt")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestClient_GetVectorStore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		vectorStoreID  string
+		serverResponse *VectorStore
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name:          "successful retrieval",
+			vectorStoreID: "vs_123",
+			serverResponse: &VectorStore{
+				ID:         "vs_123",
+				Object:     "vector_store",
+				Name:       "Docs",
+				Status:     "in_progress",
+				FileCounts: FileCounts{InProgress: 1, Completed: 4, Total: 5},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "not found",
+			vectorStoreID: "vs_123",
+			serverStatus:  http.StatusNotFound,
+			expectedError: true,
+		},
+		{
+			name:          "empty vector store ID",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores/vs_123", r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.GetVectorStore(context.Background(), tt.vectorStoreID)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_WaitForVectorStoreCompletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		status      string
+		expectError bool
+	}{
+		{
+			name:   "completed",
+			status: "completed",
+		},
+		{
+			name:        "failed",
+			status:      "failed",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores/vs_123", r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				json.NewEncoder(w).Encode(VectorStore{ID: "vs_123", Status: tt.status})
+			}))
+			defer server.Close()
+
+			type ctxKey struct{}
+			ctx := context.WithValue(context.Background(), ctxKey{}, "trace")
+
+			httpClient := server.Client()
+			transport := httpClient.Transport
+			httpClient.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				require.Equal(t, "trace", r.Context().Value(ctxKey{}), "request must carry the caller's context")
+				return transport.RoundTrip(r)
+			})
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", httpClient, WithBaseURL(server.URL))
+
+			err := client.WaitForVectorStoreCompletion(ctx, "vs_123", time.Second, time.Second)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_WaitForVectorStoreCompletion_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(VectorStore{ID: "vs_123", Status: "in_progress"})
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.WaitForVectorStoreCompletion(ctx, "vs_123", time.Minute, time.Minute)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second, "the wait must stop when the context is done")
+}
+
+func TestClient_CreateVectorStoreFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		vectorStoreID  string
+		fileID         string
+		serverResponse *VectorStoreFile
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name:          "successful creation",
+			vectorStoreID: "vs_123",
+			fileID:        "file-123",
+			serverResponse: &VectorStoreFile{
+				ID:            "file-123",
+				Object:        "vector_store.file",
+				VectorStoreID: "vs_123",
+				Status:        VectorStoreFileStatusInProgress,
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "api error",
+			vectorStoreID: "vs_123",
+			fileID:        "file-123",
+			serverStatus:  http.StatusNotFound,
+			expectedError: true,
+		},
+		{
+			name:          "missing file ID",
+			vectorStoreID: "vs_123",
+			expectedError: true,
+		},
+		{
+			name:          "missing vector store ID",
+			fileID:        "file-123",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores/vs_123/files", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				require.Equal(t, map[string]string{"file_id": tt.fileID}, body)
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.CreateVectorStoreFile(context.Background(), tt.vectorStoreID, tt.fileID)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_ListVectorStoreFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		params         ListParams
+		expectedQuery  string
+		serverResponse *VectorStoreFileList
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name: "default params",
+			serverResponse: &VectorStoreFileList{
+				Object: "list",
+				Data: []VectorStoreFile{
+					{ID: "file-1", Status: VectorStoreFileStatusCompleted},
+					{
+						ID:        "file-2",
+						Status:    VectorStoreFileStatusFailed,
+						LastError: &VectorStoreFileError{Code: "unsupported_file", Message: "The file type is not supported."},
+					},
+				},
+				FirstID: "file-1",
+				LastID:  "file-2",
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "paginated",
+			params:        ListParams{Limit: 2, After: "file-2"},
+			expectedQuery: "after=file-2&limit=2",
+			serverResponse: &VectorStoreFileList{
+				Object:  "list",
+				Data:    []VectorStoreFile{{ID: "file-3"}},
+				FirstID: "file-3",
+				LastID:  "file-3",
+				HasMore: true,
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "not found",
+			serverStatus:  http.StatusNotFound,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores/vs_123/files", r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, tt.expectedQuery, r.URL.RawQuery)
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.ListVectorStoreFiles(context.Background(), "vs_123", tt.params)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_CreateVectorStoreFileBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		fileIDs        []string
+		serverResponse *VectorStoreFileBatch
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name:    "successful creation",
+			fileIDs: []string{"file-1", "file-2"},
+			serverResponse: &VectorStoreFileBatch{
+				ID:            "vsfb_123",
+				Object:        "vector_store.file_batch",
+				VectorStoreID: "vs_123",
+				Status:        VectorStoreFileStatusInProgress,
+				FileCounts:    FileCounts{InProgress: 2, Total: 2},
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "api error",
+			fileIDs:       []string{"file-1"},
+			serverStatus:  http.StatusBadRequest,
+			expectedError: true,
+		},
+		{
+			name:          "no files",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores/vs_123/file_batches", r.URL.Path)
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				var body struct {
+					FileIDs []string `json:"file_ids"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				require.Equal(t, tt.fileIDs, body.FileIDs)
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.CreateVectorStoreFileBatch(context.Background(), "vs_123", tt.fileIDs)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_WaitForFileBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		statuses    []string
+		wantCounts  FileCounts
+		expectError bool
+	}{
+		{
+			name:       "completed after polling",
+			statuses:   []string{VectorStoreFileStatusInProgress, VectorStoreFileStatusInProgress, VectorStoreFileStatusCompleted},
+			wantCounts: FileCounts{Completed: 2, Failed: 1, Total: 3},
+		},
+		{
+			name:        "failed",
+			statuses:    []string{VectorStoreFileStatusInProgress, VectorStoreFileStatusFailed},
+			wantCounts:  FileCounts{Failed: 3, Total: 3},
+			expectError: true,
+		},
+		{
+			name:        "cancelled",
+			statuses:    []string{VectorStoreFileStatusCancelled},
+			wantCounts:  FileCounts{Cancelled: 3, Total: 3},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var polls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores/vs_123/file_batches/vsfb_123", r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+
+				batch := VectorStoreFileBatch{ID: "vsfb_123", Status: tt.statuses[polls]}
+				if polls == len(tt.statuses)-1 {
+					batch.FileCounts = tt.wantCounts
+				} else {
+					batch.FileCounts = FileCounts{InProgress: 3, Total: 3}
+				}
+				polls++
+				json.NewEncoder(w).Encode(batch)
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			batch, err := client.WaitForFileBatch(context.Background(), "vs_123", "vsfb_123", WithPollInterval(time.Millisecond))
+			require.Equal(t, len(tt.statuses), polls)
+			require.NotNil(t, batch)
+			require.Equal(t, tt.wantCounts, batch.FileCounts)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClient_ListVectorStores(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		params         ListParams
+		expectedQuery  string
+		serverResponse *VectorStoreList
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name: "default params",
+			serverResponse: &VectorStoreList{
+				Object:  "list",
+				Data:    []VectorStore{{ID: "vs_1", Name: "Docs"}, {ID: "vs_2", Name: "Tests"}},
+				FirstID: "vs_1",
+				LastID:  "vs_2",
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "paginated",
+			params:        ListParams{Limit: 1, Order: OrderDesc, After: "vs_1"},
+			expectedQuery: "after=vs_1&limit=1&order=desc",
+			serverResponse: &VectorStoreList{
+				Object:  "list",
+				Data:    []VectorStore{{ID: "vs_2"}},
+				FirstID: "vs_2",
+				LastID:  "vs_2",
+				HasMore: true,
+			},
+			serverStatus: http.StatusOK,
+		},
+		{
+			name:          "server error",
+			serverStatus:  http.StatusUnauthorized,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores", r.URL.Path)
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+				require.Equal(t, tt.expectedQuery, r.URL.RawQuery)
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.ListVectorStores(context.Background(), tt.params)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.serverResponse, result)
+		})
+	}
+}
+
+func TestClient_DeleteVectorStore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		vectorStoreID  string
+		serverResponse *DeletionStatus
+		serverStatus   int
+		expectedError  bool
+	}{
+		{
+			name:           "successful deletion",
+			vectorStoreID:  "vs_123",
+			serverResponse: &DeletionStatus{ID: "vs_123", Object: "vector_store.deleted", Deleted: true},
+			serverStatus:   http.StatusOK,
+		},
+		{
+			name:           "not deleted",
+			vectorStoreID:  "vs_123",
+			serverResponse: &DeletionStatus{ID: "vs_123", Object: "vector_store.deleted"},
+			serverStatus:   http.StatusOK,
+			expectedError:  true,
+		},
+		{
+			name:          "not found",
+			vectorStoreID: "vs_123",
+			serverStatus:  http.StatusNotFound,
+			expectedError: true,
+		},
+		{
+			name:          "empty vector store ID",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/vector_stores/vs_123", r.URL.Path)
+				require.Equal(t, http.MethodDelete, r.Method)
+				require.Equal(t, "assistants=v2", r.Header.Get("OpenAI-Beta"))
+
+				w.WriteHeader(tt.serverStatus)
+				if tt.serverResponse != nil {
+					json.NewEncoder(w).Encode(tt.serverResponse)
+				}
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			err := client.DeleteVectorStore(context.Background(), tt.vectorStoreID)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package openai
+
+import (
+	"context"
+	"time"
+)
+
+// Default polling of WaitForRun, a fixed one second interval.
+const (
+	defaultPollInterval = time.Second
+	defaultPollBackoff  = 1.0
+)
+
+// WaitOption tunes how WaitForRun polls a run, how WaitForFileBatch polls a
+// file batch, and how WaitUntilThreadIdle polls the latest run of a thread.
+type WaitOption func(*waitConfig)
+
+// WithPollInterval sets the delay before the second poll of the run.
+func WithPollInterval(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.interval = d
+	}
+}
+
+// WithMaxPollInterval caps the delay between polls as it grows with the
+// backoff factor.
+func WithMaxPollInterval(d time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.maxInterval = d
+	}
+}
+
+// WithPollBackoff multiplies the delay between polls by factor after each
+// poll, up to the max poll interval. Factors below 1 are treated as 1.
+func WithPollBackoff(factor float64) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.backoff = factor
+	}
+}
+
+// WithToolCallHandler makes WaitForRun call handler whenever the run requires
+// action, submit the outputs it returns and keep waiting.
+func WithToolCallHandler(handler ToolCallHandler) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.toolHandler = handler
+	}
+}
+
+// waitConfig tunes how waitForRun polls a run.
+type waitConfig struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	backoff     float64
+
+	// onStatus is invoked with the run every time its status changes.
+	onStatus func(*Run)
+	// toolHandler computes the outputs of the tool calls the run requires.
+	toolHandler ToolCallHandler
+	// stopOnAction makes waitForRun return the run once it requires action
+	// instead of waiting for someone else to submit the tool outputs.
+	stopOnAction bool
+	// answered holds the tool calls whose outputs were just submitted. A run
+	// still requiring only those has not processed the outputs yet, so
+	// stopOnAction keeps polling it.
+	answered map[string]bool
+	// sleep waits between polls. Tests replace it to poll without delay.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newWaitConfig(opts []WaitOption) waitConfig {
+	cfg := waitConfig{
+		interval: defaultPollInterval,
+		backoff:  defaultPollBackoff,
+		sleep:    sleepContext,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.interval <= 0 {
+		cfg.interval = defaultPollInterval
+	}
+	if cfg.maxInterval < cfg.interval {
+		cfg.maxInterval = cfg.interval
+		if cfg.backoff > 1 {
+			// A backoff without an explicit cap grows up to a minute.
+			cfg.maxInterval = max(time.Minute, cfg.interval)
+		}
+	}
+	if cfg.backoff < 1 {
+		cfg.backoff = 1
+	}
+	return cfg
+}
+
+// nextInterval returns the delay to wait after the one that was just waited.
+func (cfg waitConfig) nextInterval(d time.Duration) time.Duration {
+	return min(time.Duration(float64(d)*cfg.backoff), cfg.maxInterval)
+}
+
+// sleepContext waits for d, returning early with the context's error when it
+// is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
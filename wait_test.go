@@ -0,0 +1,206 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withoutSleep makes the wait poll without delay, recording the delays it
+// would have waited in sleeps when not nil.
+func withoutSleep(mu *sync.Mutex, sleeps *[]time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.sleep = func(ctx context.Context, d time.Duration) error {
+			if sleeps != nil {
+				mu.Lock()
+				*sleeps = append(*sleeps, d)
+				mu.Unlock()
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+func TestNewWaitConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      []WaitOption
+		wantDelay []time.Duration
+	}{
+		{
+			name:      "default fixed interval",
+			wantDelay: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name:      "custom fixed interval",
+			opts:      []WaitOption{WithPollInterval(200 * time.Millisecond)},
+			wantDelay: []time.Duration{200 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name: "backoff with cap",
+			opts: []WaitOption{
+				WithPollInterval(100 * time.Millisecond),
+				WithPollBackoff(2),
+				WithMaxPollInterval(300 * time.Millisecond),
+			},
+			wantDelay: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			name:      "backoff without cap",
+			opts:      []WaitOption{WithPollInterval(30 * time.Second), WithPollBackoff(3)},
+			wantDelay: []time.Duration{30 * time.Second, time.Minute, time.Minute},
+		},
+		{
+			name:      "invalid values fall back",
+			opts:      []WaitOption{WithPollInterval(-time.Second), WithPollBackoff(0.5)},
+			wantDelay: []time.Duration{time.Second, time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newWaitConfig(tt.opts)
+			delay := cfg.interval
+			for i, want := range tt.wantDelay {
+				require.Equal(t, want, delay, "delay %d", i)
+				delay = cfg.nextInterval(delay)
+			}
+		})
+	}
+}
+
+func TestClient_WaitForRun_CancelledWhileSleeping(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Run{ID: "run_456", Status: RunStatusInProgress})
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.WaitForRun(ctx, "thread_123", "run_456", WithPollInterval(time.Hour))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestClient_WaitForRun_ToolCallHandler(t *testing.T) {
+	t.Parallel()
+
+	requiresAction := Run{
+		ID:     "run_456",
+		Status: RunStatusRequiresAction,
+		RequiredAction: &RequiredAction{
+			Type: "submit_tool_outputs",
+			ToolCalls: []ToolCall{{
+				ID:       "call_1",
+				Type:     ToolTypeFunction,
+				Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Lisbon"}`},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		handler     ToolCallHandler
+		wantOutputs []ToolOutput
+		expectErrIs []error
+		expectError bool
+	}{
+		{
+			name: "submits handler outputs",
+			handler: func(_ context.Context, calls []ToolCall) ([]ToolOutput, error) {
+				return []ToolOutput{{ToolCallID: calls[0].ID, Output: "sunny"}}, nil
+			},
+			wantOutputs: []ToolOutput{{ToolCallID: "call_1", Output: "sunny"}},
+		},
+		{
+			name: "handler error",
+			handler: func(context.Context, []ToolCall) ([]ToolOutput, error) {
+				return nil, errors.New("weather service down")
+			},
+			expectError: true,
+		},
+		{
+			name:        "no handler",
+			expectErrIs: []error{ErrRunRequiresAction, context.DeadlineExceeded},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu        sync.Mutex
+				submitted []ToolOutput
+			)
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /threads/thread_123/runs/run_456", func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if submitted != nil {
+					json.NewEncoder(w).Encode(Run{ID: "run_456", Status: RunStatusCompleted})
+					return
+				}
+				json.NewEncoder(w).Encode(requiresAction)
+			})
+			mux.HandleFunc("POST /threads/thread_123/runs/run_456/submit_tool_outputs", func(w http.ResponseWriter, r *http.Request) {
+				var input struct {
+					ToolOutputs []ToolOutput `json:"tool_outputs"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+				mu.Lock()
+				submitted = input.ToolOutputs
+				mu.Unlock()
+
+				json.NewEncoder(w).Encode(Run{ID: "run_456", Status: RunStatusQueued})
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			err := client.WaitForRun(ctx, "thread_123", "run_456",
+				WithPollInterval(10*time.Millisecond),
+				WithToolCallHandler(tt.handler),
+			)
+			if tt.expectError {
+				require.Error(t, err)
+				for _, target := range tt.expectErrIs {
+					require.ErrorIs(t, err, target)
+				}
+				require.Nil(t, submitted)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOutputs, submitted)
+		})
+	}
+}
@@ -1,73 +1,114 @@
 package openai
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
-	"time"
-
-	"github.com/wiselead-ai/httpclient"
+	"strconv"
 )
 
-const (
-	whisperModel   = "whisper-1"
-	defaultTimeout = 30 * time.Second
-)
+const whisperModel = "whisper-1"
 
-// TranscribeAudio transcribes the audio from the given input.
-func (c *Client) TranscribeAudio(in TranscribeAudioInput) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
-	defer cancel()
+// TranscribeAudio transcribes the audio from the given input and returns the
+// transcription in in.ResponseFormat, plain text by default, such as SRT or
+// VTT subtitles. Long recordings can take minutes to transcribe, which the
+// deadline of ctx, if any, must allow for.
+func (c *Client) TranscribeAudio(ctx context.Context, in TranscribeAudioInput) (_ []byte, err error) {
+	ctx, op := c.startOperation(ctx, "TranscribeAudio")
+	defer op.end(&err)
 
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
+	format := in.ResponseFormat
+	switch format {
+	case "":
+		format = TranscriptionFormatText
+	case TranscriptionFormatText, TranscriptionFormatJSON, TranscriptionFormatVerboseJSON,
+		TranscriptionFormatSRT, TranscriptionFormatVTT:
+	default:
+		return nil, fmt.Errorf("unsupported response format '%s'", format)
+	}
 
-	part, err := writer.CreateFormFile("file", in.Name)
+	response, err := c.transcribe(ctx, in, format)
 	if err != nil {
-		return nil, fmt.Errorf("could not create form file: %w", err)
+		return nil, err
 	}
+	defer response.Body.Close()
 
-	if _, err := io.Copy(part, in.Data); err != nil {
-		return nil, fmt.Errorf("could not copy data: %w", err)
+	b, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body: %w", err)
 	}
+	return b, nil
+}
+
+// TranscribeAudioVerbose transcribes the audio from the given input, keeping
+// the timed segments of the transcription. Whisper doesn't tell speakers
+// apart, but the segments can be used to split the text into speaker turns,
+// helped by a prompt naming the speakers.
+func (c *Client) TranscribeAudioVerbose(ctx context.Context, in TranscribeAudioInput) (_ *Transcription, err error) {
+	ctx, op := c.startOperation(ctx, "TranscribeAudioVerbose")
+	defer op.end(&err)
 
-	if err := writer.WriteField("model", whisperModel); err != nil {
-		return nil, fmt.Errorf("could not write model field: %w", err)
+	response, err := c.transcribe(ctx, in, TranscriptionFormatVerboseJSON)
+	if err != nil {
+		return nil, err
 	}
+	defer response.Body.Close()
 
-	if err := writer.WriteField("response_format", "text"); err != nil {
-		return nil, fmt.Errorf("could not write response_format field: %w", err)
+	var transcription Transcription
+	if err := json.NewDecoder(response.Body).Decode(&transcription); err != nil {
+		return nil, fmt.Errorf("could not decode response: %w", err)
 	}
+	return &transcription, nil
+}
 
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("could not close writer: %w", err)
+// transcribe sends the transcription request for the response format and
+// returns the successful response, whose body the caller must close.
+func (c *Client) transcribe(ctx context.Context, in TranscribeAudioInput, format string) (*http.Response, error) {
+	if t := in.Temperature; t != nil && (*t < 0 || *t > 1) {
+		return nil, fmt.Errorf("temperature %g is out of range, must be between 0 and 1", *t)
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/audio/transcriptions", &body)
-	if err != nil {
-		return nil, fmt.Errorf("could not create request: %w", err)
+	model := in.Model
+	if model == "" {
+		model = whisperModel
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.apiKey)
-	request.Header.Set("Content-Type", writer.FormDataContentType())
+	fields := map[string]string{
+		"model":           string(model),
+		"response_format": format,
+	}
+	if in.Prompt != "" {
+		fields["prompt"] = in.Prompt
+	}
+	if in.Language != "" {
+		fields["language"] = in.Language
+	}
+	if in.Temperature != nil {
+		fields["temperature"] = strconv.FormatFloat(*in.Temperature, 'f', -1, 64)
+	}
 
-	response, err := httpclient.DoWithRetry(c.httpClient, request)
+	body, contentType, err := buildMultipart(fields, fileField{name: "file", filename: in.Name, data: in.Data})
 	if err != nil {
-		return nil, fmt.Errorf("sending request: %w", err)
+		return nil, fmt.Errorf("could not build multipart body: %w", err)
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(response.Body)
-		return nil, fmt.Errorf("unexpected status code '%d', response: '%s'", response.StatusCode, string(respBody))
+	request, err := c.newRequest(ctx, http.MethodPost, "/audio/transcriptions", body)
+	if err != nil {
+		return nil, err
 	}
 
-	b, err := io.ReadAll(response.Body)
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := c.doWithRetry(request)
 	if err != nil {
-		return nil, fmt.Errorf("could not read response body: %w", err)
+		return nil, fmt.Errorf("sending request: %w", err)
 	}
-	return b, nil
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, newAPIError(response)
+	}
+	return response, nil
 }
@@ -2,6 +2,7 @@ package openai
 
 import (
 	"bytes"
+	"context"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -68,8 +69,9 @@ func TestClient_TranscribeAudio(t *testing.T) {
 
 			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+			client.retrySleep = withoutRetryDelay(nil, nil)
 
-			result, err := client.TranscribeAudio(tt.input)
+			result, err := client.TranscribeAudio(context.Background(), tt.input)
 			if tt.expectError {
 				require.Error(t, err)
 				return
@@ -80,3 +82,167 @@ func TestClient_TranscribeAudio(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_TranscribeAudio_Options(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		input           TranscribeAudioInput
+		wantModel       string
+		wantFormat      string
+		wantLanguage    string
+		wantTemperature string
+		expectError     bool
+	}{
+		{
+			name:       "text by default",
+			input:      TranscribeAudioInput{Name: "talk.mp3"},
+			wantModel:  "whisper-1",
+			wantFormat: "text",
+		},
+		{
+			name:       "newer model",
+			input:      TranscribeAudioInput{Name: "talk.mp3", Model: "gpt-4o-transcribe"},
+			wantModel:  "gpt-4o-transcribe",
+			wantFormat: "text",
+		},
+		{
+			name: "subtitles in french",
+			input: TranscribeAudioInput{
+				Name:           "talk.mp3",
+				ResponseFormat: TranscriptionFormatSRT,
+				Language:       "fr",
+				Temperature:    ptr(0.2),
+			},
+			wantModel:       "whisper-1",
+			wantFormat:      "srt",
+			wantLanguage:    "fr",
+			wantTemperature: "0.2",
+		},
+		{
+			name:        "unsupported format",
+			input:       TranscribeAudioInput{Name: "talk.mp3", ResponseFormat: "docx"},
+			expectError: true,
+		},
+		{
+			name:        "temperature out of range",
+			input:       TranscribeAudioInput{Name: "talk.mp3", Temperature: ptr(1.5)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseMultipartForm(32<<20))
+				require.Equal(t, tt.wantModel, r.FormValue("model"))
+				require.Equal(t, tt.wantFormat, r.FormValue("response_format"))
+				require.Equal(t, tt.wantLanguage, r.FormValue("language"))
+				require.Equal(t, tt.wantTemperature, r.FormValue("temperature"))
+
+				w.Write([]byte("1\n00:00:00,000 --> 00:00:01,500\nBonjour.\n"))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			tt.input.Data = bytes.NewReader([]byte("fake audio data"))
+			result, err := client.TranscribeAudio(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Contains(t, string(result), "Bonjour.")
+		})
+	}
+}
+
+func TestClient_TranscribeAudioVerbose(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        TranscribeAudioInput
+		wantPrompt   string
+		serverResp   string
+		serverStatus int
+		want         *Transcription
+		expectError  bool
+	}{
+		{
+			name: "segments with prompt",
+			input: TranscribeAudioInput{
+				Name:   "meeting.mp3",
+				Data:   bytes.NewReader([]byte("fake audio data")),
+				Prompt: "Alice and Bob discuss the roadmap.",
+			},
+			wantPrompt: "Alice and Bob discuss the roadmap.",
+			serverResp: `{
+				"task": "transcribe",
+				"language": "english",
+				"duration": 4.2,
+				"text": "Hi Bob. Hi Alice.",
+				"segments": [
+					{"id": 0, "seek": 0, "start": 0, "end": 1.5, "text": " Hi Bob.", "tokens": [50364, 2421], "no_speech_prob": 0.01},
+					{"id": 1, "seek": 0, "start": 2.1, "end": 4.2, "text": " Hi Alice.", "tokens": [50464, 2421]}
+				]
+			}`,
+			serverStatus: http.StatusOK,
+			want: &Transcription{
+				Task:     "transcribe",
+				Language: "english",
+				Duration: 4.2,
+				Text:     "Hi Bob. Hi Alice.",
+				Segments: []TranscriptionSegment{
+					{ID: 0, Start: 0, End: 1.5, Text: " Hi Bob.", Tokens: []int{50364, 2421}, NoSpeechProb: 0.01},
+					{ID: 1, Start: 2.1, End: 4.2, Text: " Hi Alice.", Tokens: []int{50464, 2421}},
+				},
+			},
+		},
+		{
+			name: "bad request",
+			input: TranscribeAudioInput{
+				Name: "empty.mp3",
+				Data: bytes.NewReader([]byte{}),
+			},
+			serverResp:   `{"error":{"message":"Audio file is too short"}}`,
+			serverStatus: http.StatusBadRequest,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/audio/transcriptions", r.URL.Path)
+				require.NoError(t, r.ParseMultipartForm(32<<20))
+				require.Equal(t, "verbose_json", r.FormValue("response_format"))
+				require.Equal(t, tt.wantPrompt, r.FormValue("prompt"))
+
+				w.WriteHeader(tt.serverStatus)
+				w.Write([]byte(tt.serverResp))
+			}))
+			defer server.Close()
+
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			client := New(logger, "test-key", server.Client(), WithBaseURL(server.URL))
+
+			result, err := client.TranscribeAudioVerbose(context.Background(), tt.input)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, result)
+		})
+	}
+}